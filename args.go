@@ -0,0 +1,36 @@
+package assert
+
+import "fmt"
+
+// badKey is the reserved key holding a trailing value that has no key, as in log/slog
+const badKey = "!BADKEY"
+
+// WithDebugMode echoes the raw arguments of every failure and warns about malformed
+// key/value pairs
+func WithDebugMode() Option {
+	return func(a *AssertHandler) {
+		a.debugMode = true
+	}
+}
+
+// appendArgs copies alternating key/value args into data. Non-string keys are converted
+// with fmt.Sprint and a trailing value without a key is stored under "!BADKEY".
+// It returns a description of every malformed pair.
+func appendArgs(data map[string]interface{}, args []interface{}) []string {
+	var problems []string
+	for i := 0; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			data[badKey] = args[i]
+			problems = append(problems, fmt.Sprintf("value %v at position %d has no key", args[i], i))
+			break
+		}
+
+		key, ok := args[i].(string)
+		if !ok {
+			key = fmt.Sprint(args[i])
+			problems = append(problems, fmt.Sprintf("key %v at position %d is a %T, not a string", args[i], i, args[i]))
+		}
+		data[key] = args[i+1]
+	}
+	return problems
+}
@@ -0,0 +1,24 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestMalformedArgs(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithDebugMode())
+
+	handler.Assert(context.TODO(), false, "Malformed Args", 42, "answer", "orphan")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("42=answer")) {
+		t.Fatalf("Expected non-string key to be coerced, got %s", buffer.String())
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("!BADKEY=orphan")) {
+		t.Fatalf("Expected orphaned value under !BADKEY")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("assert: WARNING:")) {
+		t.Fatalf("Expected debug mode warning")
+	}
+}
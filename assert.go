@@ -2,26 +2,76 @@ package assert
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log/slog"
+	"io/fs"
 	"os"
-	"runtime/debug"
-	"strings"
 	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 )
 
-// Define the AssertHandler to encapsulate state
+// Define the AssertHandler to encapsulate state.
+// A handler is safe for concurrent use: flushLock guards every field below and serializes
+// failure reporting, while deferred failures live in a separately locked store.
 type AssertHandler struct {
 	flushes         []AssertFlush
+	exporters       []Exporter
 	assertData      map[string]AssertData
 	writer          io.Writer
 	flushLock       sync.Mutex
-	exitFunc        func(code int)
-	formatter       Formatter
-	deferredErrors  []string
+	failurePolicy   FailurePolicy
+	formatter       EventFormatter
+	deferred        *deferredStore
 	deferAssertions bool
+	tags            []any
+	errorSeverity   func(error) Severity
+	contextKeys     map[string]any
+	crashFile       string
+	exitPanic       bool
+	groups          []*AssertGroup
+	kindActions     map[Kind]Action
+	routes          map[Kind]FailurePolicy
+	severityRoutes  map[Severity]FailurePolicy
+
+	strictControlFlow bool
+	debugMode         bool
+	isolated          bool
+	reported          atomic.Int64
+	spoolDir          string
+	spoolThreshold    int
+	numberSeparator   string
+	separatedKeys     map[string]bool
+	humanDurations    bool
+	timeLayout        string
+	maxDeferred       int
+	deferUntil        int
+	collectionBudget  time.Duration
+	debuggerBreak     bool
+	deferredPolicy    DeferredPolicy
+	userMessage       *template.Template
+	taxonomy          *taxonomy
+	policy            *policyState
+	history           *history
+	stats             *assertStats
+	countPasses       atomic.Bool
+	callSites         *callSiteCounts
+	reportFirst       int
+	fieldProviders    []FieldProvider
+	flagProvider      func(name string) bool
+	writers           []teeWriter
+	rollouts          map[string]Rollout
+	onFailure         []FailureHook
+	framing           RecordFraming
+	flushMode         FlushMode
+	dumpDir           string
+	allStacks         bool
+	twoStage          *twoStage
+	fileSystem        fs.FS
+	flushTimeout      time.Duration
+	abandoned         *atomic.Uint64
 }
 
 // Define interfaces for logging/asserting
@@ -34,144 +84,401 @@ type AssertFlush interface {
 }
 
 // Add a constructor for the handler
-func NewAssertHandler() *AssertHandler {
-	return &AssertHandler{
+func NewAssertHandler(opts ...Option) *AssertHandler {
+	a := &AssertHandler{
 		flushes:         []AssertFlush{},
+		exporters:       []Exporter{},
 		assertData:      make(map[string]AssertData),
 		writer:          os.Stderr,
-		exitFunc:        os.Exit,          // Default exit behavior
-		formatter:       &TextFormatter{}, // Default to text formatter
-		deferredErrors:  []string{},
+		failurePolicy:   ExitPolicy{},                      // Default exit behavior
+		formatter:       legacyFormatter{&TextFormatter{}}, // Default to text formatter
+		deferred:        &deferredStore{},
+		taxonomy:        &taxonomy{},
+		policy:          newPolicyState(),
+		history:         newHistory(),
+		stats:           newAssertStats(),
+		callSites:       newCallSiteCounts(),
 		deferAssertions: false,
+		contextKeys:     make(map[string]any),
+		kindActions:     make(map[Kind]Action),
+		routes:          make(map[Kind]FailurePolicy),
+		severityRoutes:  make(map[Severity]FailurePolicy),
+		flushTimeout:    defaultFlushTimeout,
+		abandoned:       &atomic.Uint64{},
 	}
+	a.apply(opts)
+	return a
+}
+
+// clone returns a handler sharing a's configuration, with its own copies of the mutable collections
+func (a *AssertHandler) clone() *AssertHandler {
+	child := &AssertHandler{
+		flushes:           append([]AssertFlush{}, a.flushes...),
+		exporters:         append([]Exporter{}, a.exporters...),
+		assertData:        make(map[string]AssertData, len(a.assertData)),
+		writer:            a.writer,
+		failurePolicy:     a.failurePolicy,
+		formatter:         a.formatter,
+		deferred:          a.deferred,
+		deferAssertions:   a.deferAssertions,
+		tags:              append([]any{}, a.tags...),
+		errorSeverity:     a.errorSeverity,
+		contextKeys:       make(map[string]any, len(a.contextKeys)),
+		crashFile:         a.crashFile,
+		exitPanic:         a.exitPanic,
+		strictControlFlow: a.strictControlFlow,
+		debugMode:         a.debugMode,
+		isolated:          a.isolated,
+		spoolDir:          a.spoolDir,
+		spoolThreshold:    a.spoolThreshold,
+		numberSeparator:   a.numberSeparator,
+		separatedKeys:     a.separatedKeys,
+		humanDurations:    a.humanDurations,
+		timeLayout:        a.timeLayout,
+		maxDeferred:       a.maxDeferred,
+		deferUntil:        a.deferUntil,
+		collectionBudget:  a.collectionBudget,
+		debuggerBreak:     a.debuggerBreak,
+		deferredPolicy:    a.deferredPolicy,
+		userMessage:       a.userMessage,
+		taxonomy:          a.taxonomy,
+		policy:            a.policy,
+		history:           a.history,
+		stats:             a.stats,
+		callSites:         a.callSites,
+		reportFirst:       a.reportFirst,
+		fieldProviders:    append([]FieldProvider{}, a.fieldProviders...),
+		flagProvider:      a.flagProvider,
+		writers:           append([]teeWriter{}, a.writers...),
+		onFailure:         append([]FailureHook{}, a.onFailure...),
+		framing:           a.framing,
+		flushMode:         a.flushMode,
+		dumpDir:           a.dumpDir,
+		allStacks:         a.allStacks,
+		twoStage:          a.twoStage,
+		fileSystem:        a.fileSystem,
+		flushTimeout:      a.flushTimeout,
+		abandoned:         a.abandoned,
+		kindActions:       make(map[Kind]Action, len(a.kindActions)),
+		routes:            make(map[Kind]FailurePolicy, len(a.routes)),
+		severityRoutes:    make(map[Severity]FailurePolicy, len(a.severityRoutes)),
+		rollouts:          make(map[string]Rollout, len(a.rollouts)),
+	}
+	child.countPasses.Store(a.countPasses.Load())
+	for k, v := range a.assertData {
+		child.assertData[k] = v
+	}
+	for k, v := range a.contextKeys {
+		child.contextKeys[k] = v
+	}
+	for k, v := range a.kindActions {
+		child.kindActions[k] = v
+	}
+	for k, v := range a.routes {
+		child.routes[k] = v
+	}
+	for k, v := range a.severityRoutes {
+		child.severityRoutes[k] = v
+	}
+	for k, v := range a.rollouts {
+		child.rollouts[k] = v
+	}
+	return child
 }
 
 // SetDeferAssertions allows toggling deferred assertion mode
 func (a *AssertHandler) SetDeferAssertions(deferMode bool) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
 	a.deferAssertions = deferMode
 }
 
 func (a *AssertHandler) SetFormatter(formatter Formatter) {
-	a.formatter = formatter
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	a.formatter = asEventFormatter(formatter)
 }
 
+// SetExitFunc sets the function called with the exit code after a failure, replacing the
+// failure policy with an ExitPolicy calling it
 func (a *AssertHandler) SetExitFunc(exitFunc func(int)) {
-	a.exitFunc = exitFunc
+	a.SetFailurePolicy(ExitPolicy{Exit: exitFunc})
 }
 
+// AddAssertData registers data dumped on every failure. Keys may be namespaced with "/",
+// e.g. "mylib/cache", which renders the dump nested under "mylib".
 func (a *AssertHandler) AddAssertData(key string, value AssertData) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
 	a.assertData[key] = value
 }
 
 func (a *AssertHandler) RemoveAssertData(key string) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
 	delete(a.assertData, key)
 }
 
 func (a *AssertHandler) AddAssertFlush(flusher AssertFlush) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
 	a.flushes = append(a.flushes, flusher)
 }
 
 func (a *AssertHandler) ToWriter(w io.Writer) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
 	a.writer = w
 }
 
 func (a *AssertHandler) runAssert(ctx context.Context, msg string, args ...interface{}) {
+	a.runAssertWithSeverity(ctx, SeverityError, msg, args...)
+}
+
+func (a *AssertHandler) runAssertWithSeverity(ctx context.Context, severity Severity, msg string, args ...interface{}) {
+	a.report(ctx, failure{severity: severity, msg: msg, args: args})
+}
+
+// failure describes a single failed check on its way through report
+type failure struct {
+	severity Severity
+	kind     Kind
+	msg      string
+	args     []interface{}
+}
+
+func (a *AssertHandler) report(ctx context.Context, f failure) {
+	// per-call options apply to a derived handler sharing this handler's deferred failures
+	args, opts := splitCallArgs(f.args)
+	f.args = args
+	if len(opts) > 0 {
+		a.With(opts...).report(ctx, f)
+		return
+	}
+
+	severity, msg := f.severity, f.msg
+
+	// deferred failures reaching a configured bound are processed once the lock is released
+	var processPending func()
+	defer func() {
+		if processPending != nil {
+			processPending()
+		}
+	}()
+
 	a.flushLock.Lock()
 	defer a.flushLock.Unlock()
 
-	// Check if the context has been canceled
-	if err := ctx.Err(); err != nil {
+	// Check if the context has been canceled. Failures under an expired deadline are still
+	// reported since the deadline data is what explains them.
+	if err := ctx.Err(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		if cause := context.Cause(ctx); cause != nil && cause != err {
+			fmt.Fprintln(a.writer, "Context canceled:", err, "cause:", cause)
+			return
+		}
 		fmt.Fprintln(a.writer, "Context canceled:", err)
 		return
 	}
 
+	if a.limitCallSite() {
+		return
+	}
+
+	a.recordFailure(f.kind)
+	a.reported.Add(1)
+
 	// Prevent re-entrancy by skipping further flushes
-	for _, f := range a.flushes {
-		f.Flush()
+	for _, flusher := range a.flushes {
+		flusher.Flush()
 	}
 
 	data := map[string]interface{}{
-		"msg":  msg,
-		"area": "Assert",
+		"msg":      msg,
+		"area":     "Assert",
+		"severity": severity.String(),
+		"caller":   callSiteLocation(),
 	}
+	budget := a.newCollectionBudget()
+
+	a.addContextData(ctx, data)
+
+	// static tags go first so per-call args can override them
+	args = append(append([]any{}, a.tags...), args...)
 
 	// append the args to the data
-	for i := 0; i < len(args); i += 2 {
-		if i+1 >= len(args) {
-			break
+	if problems := appendArgs(data, args); len(problems) > 0 && a.debugMode {
+		for _, problem := range problems {
+			fmt.Fprintln(a.writer, "assert: WARNING:", problem)
 		}
-		data[args[i].(string)] = args[i+1]
 	}
 
-	fmt.Fprintf(a.writer, "ARGS: %+v\n", args)
+	if a.debugMode {
+		fmt.Fprintf(a.writer, "ARGS: %+v\n", args)
+	}
+
+	resolveLazy(data)
+
+	// failures are grouped by the message template rather than the filled in message
+	template := msg
+	if expanded, ok := expandMessage(msg, data); ok {
+		msg = expanded
+		data["msg"] = msg
+		data[msgTemplateKey] = template
+	}
+	addFingerprint(template, data)
+	a.recordTaxonomy(severity, template, data)
+	a.recordHistory(data)
+
+	if a.suppress(data) {
+		return
+	}
+
+	enforced := a.enforced(ctx, data)
+	if !enforced {
+		data["enforcement"] = "record-only"
+	}
 
 	for k, v := range a.assertData {
-		data[k] = v.Dump()
+		var dump string
+		if budget.run("assert_data."+k, func() { dump = v.Dump() }) {
+			setNamespaced(data, k, dump)
+		}
 	}
 
-	stack := string(debug.Stack())
+	event := AssertionEvent{
+		Time:        time.Now(),
+		Severity:    severity,
+		Kind:        f.kind,
+		Message:     msg,
+		Data:        data,
+		Caller:      fmt.Sprint(data["caller"]),
+		Fingerprint: fmt.Sprint(data[fingerprintKey]),
+	}
+	a.provideFields(ctx, event, budget)
+
+	a.humanizeValues(data)
+	a.spoolLargeValues(data)
+
+	// under a budget the stack is still captured on this goroutine, since the budget runs
+	// collectors on their own; only rendering it is left to the budget
+	if budget == nil {
+		event.Stack = a.stack()
+	} else {
+		pcs := callerStack()
+		var stack string
+		if budget.run("stack", func() { stack = a.renderedStack(pcs) }) {
+			event.Stack = stack
+		}
+	}
+	event.Frames = callerFrames()
+
+	event, formattedOutput := a.formatWithin(budget, event)
+
+	// user-facing tools show a friendly message instead of the technical output on exit
+	userFacingExit := a.userMessage != nil && enforced && a.exitsImmediately(ctx, f)
+	if !userFacingExit {
+		a.writeRecord(a.writer, formattedOutput)
+	}
+	a.emit(ctx, event, formattedOutput)
+	a.flushAfterEvent(ctx)
+	a.history.remember(event)
+	a.runHooks(ctx, event)
+
+	// Info and Warn failures, and failures outside their code's rollout, are reported but
+	// never deferred or fatal
+	if !severity.exits() || !enforced {
+		return
+	}
 
-	formattedOutput := a.formatter.Format(data, stack)
+	switch a.kindActions[f.kind] {
+	case ActionLog:
+		return
+	case ActionPanic:
+		panic(newAssertionError(event))
+	}
 
-	fmt.Fprintln(a.writer, "ASSERT")
-	fmt.Fprintln(a.writer, formattedOutput)
+	// A deferred scope on the context collects the failure regardless of the handler mode
+	if scope := deferredScopeFrom(ctx, a.deferred); scope != nil {
+		if pending := scope.add(event, formattedOutput); a.deferUntil > 0 && pending >= a.deferUntil {
+			processPending = func() { a.processDeferredUntil(ctx, scope) }
+		}
+		return
+	}
 
 	// If we are in deferred mode, store the error and return
 	if a.deferAssertions {
-		a.deferredErrors = append(a.deferredErrors, formattedOutput)
+		pending := a.deferred.add(event, formattedOutput)
+		switch {
+		case a.maxDeferred > 0 && pending >= a.maxDeferred:
+			processPending = func() { a.failFastDeferred(ctx) }
+		case a.deferUntil > 0 && pending >= a.deferUntil:
+			processPending = func() { a.processDeferredUntil(ctx, a.deferred) }
+		}
 		return
 	}
 
-	// Use the custom exit function instead of os.Exit directly
-	a.exitFunc(1)
-}
+	if userFacingExit {
+		a.writeUserMessage(msg, data)
+	}
+
+	a.breakIntoDebugger()
+	a.prepareExit(ctx, formattedOutput)
 
-// Process all deferred assertions at once, logging or exiting if needed
-func (a *AssertHandler) ProcessDeferredAssertions(ctx context.Context) {
-	if len(a.deferredErrors) > 0 {
-		// Combine all errors into a single string
-		combinedErrors := strings.Join(a.deferredErrors, "\n---\n")
-		fmt.Fprintln(a.writer, combinedErrors)
+	if a.exitPanic {
+		panic(exitPanic{err: newAssertionError(event)})
+	}
 
-		// Clear the deferred errors after processing
-		a.deferredErrors = []string{}
+	a.failurePolicyFor(event).Handle(event)
 
-		// Exit after processing if it's an ERROR level
-		a.exitFunc(1)
+	if a.strictControlFlow {
+		panic(newAssertionError(event))
 	}
 }
 
-func (a *AssertHandler) Assert(ctx context.Context, truth bool, msg string, data ...any) {
-	if !truth {
-		a.runAssert(ctx, msg, data...)
+// format renders event with the handler's formatter, falling back to the text output when
+// the formatter fails. Callers must hold flushLock.
+func (a *AssertHandler) format(event AssertionEvent) string {
+	out, err := a.formatter.FormatEvent(event)
+	if err != nil {
+		fmt.Fprintln(a.writer, "Formatter error:", err)
+		return (&TextFormatter{}).Format(event.Data, event.Stack)
 	}
+	return string(out)
+}
+
+func (a *AssertHandler) Assert(ctx context.Context, truth bool, msg string, data ...any) {
+	a.evaluate(ctx, KindAssert, truth, msg, data)
 }
 
 func (a *AssertHandler) AssertWithTimeout(ctx context.Context, timeout time.Duration, truth bool, msg string, data ...any) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	if !truth {
-		a.runAssert(ctx, msg, data...)
-	}
+	a.evaluate(ctx, KindAssert, truth, msg, data)
 }
 
+// Nil fails when item is not nil. Typed nil pointers, maps, slices, channels and funcs
+// stored in the interface count as nil.
 func (a *AssertHandler) Nil(ctx context.Context, item any, msg string, data ...any) {
-	slog.InfoContext(ctx, "Nil Check", "item", item)
-	if item == nil {
-		a.runAssert(ctx, msg, data...)
+	if isNil(item) {
+		a.passed(KindAssert)
 		return
 	}
 
-	slog.ErrorContext(ctx, "Nil#not nil encountered")
+	a.logError(ctx, "Nil#not nil encountered")
+	data = append(data, "type", fmt.Sprintf("%T", item))
 	a.runAssert(ctx, msg, data...)
 }
 
+// NotNil fails when item is nil, including typed nils such as a nil *T stored in an interface
 func (a *AssertHandler) NotNil(ctx context.Context, item any, msg string, data ...any) {
-	if item == nil {
-		slog.ErrorContext(ctx, "NotNil#nil encountered")
+	if isNil(item) {
+		a.logError(ctx, "NotNil#nil encountered")
+		data = append(data, "type", fmt.Sprintf("%T", item))
 		a.runAssert(ctx, msg, data...)
+		return
 	}
+	a.passed(KindAssert)
 }
 
 func (a *AssertHandler) Never(ctx context.Context, msg string, data ...any) {
@@ -181,6 +488,8 @@ func (a *AssertHandler) Never(ctx context.Context, msg string, data ...any) {
 func (a *AssertHandler) NoError(ctx context.Context, err error, msg string, data ...any) {
 	if err != nil {
 		data = append(data, "error", err)
-		a.runAssert(ctx, msg, data...)
+		a.runAssertWithSeverity(ctx, a.severityForError(err), msg, data...)
+		return
 	}
+	a.passed(KindAssert)
 }
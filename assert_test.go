@@ -80,7 +80,7 @@ func TestDeferredAssertions(t *testing.T) {
 	handler.SetDeferAssertions(true)
 
 	// These should not be printed immediately
-	handler.Nil(context.TODO(), nil, "Test Deferred Nil")
+	handler.Nil(context.TODO(), "not nil", "Test Deferred Nil")
 	handler.Assert(context.TODO(), false, "Test Deferred Assert")
 
 	// Process deferred assertions
@@ -0,0 +1,21 @@
+// Package assertdebug serves the introspection data of assertion handlers over HTTP, so the
+// core package does not depend on net/http for it.
+package assertdebug
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// Handler serves the DebugInfo of a as indented JSON: the taxonomy, the per-minute failure
+// history of every code and the assertion counters. Mount it on an internal debug mux.
+func Handler(a *assert.AssertHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(a.DebugInfo())
+	})
+}
@@ -0,0 +1,31 @@
+package assertdebug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+func TestHandler(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := assert.NewAssertHandler(assert.WithWriter(&buffer), assert.WithExitFunc(func(code int) {}))
+	handler.Assert(context.TODO(), false, "Lag High", "code", "LAG")
+
+	recorder := httptest.NewRecorder()
+	Handler(handler).ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/assert", nil))
+
+	var body assert.DebugInfo
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected JSON, got %s", recorder.Body.String())
+	}
+	if h := body.History["LAG"]; len(h) != 60 || h[59] != 1 || body.Stats.Failed != 1 {
+		t.Fatalf("Expected the history and stats to be served, got %s", recorder.Body.String())
+	}
+	if recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("Expected a JSON content type")
+	}
+}
@@ -0,0 +1,25 @@
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+// Asserter is the public assertion behavior of *AssertHandler. Package-level functions
+// resolve an Asserter from the context, so tests can inject fakes and decorators can wrap handlers.
+type Asserter interface {
+	Assert(ctx context.Context, truth bool, msg string, data ...any)
+	AssertWithTimeout(ctx context.Context, timeout time.Duration, truth bool, msg string, data ...any)
+	Nil(ctx context.Context, item any, msg string, data ...any)
+	NotNil(ctx context.Context, item any, msg string, data ...any)
+	Never(ctx context.Context, msg string, data ...any)
+	NoError(ctx context.Context, err error, msg string, data ...any)
+	NoErrorEventually(ctx context.Context, fn func() error, timeout, interval time.Duration, msg string, data ...any)
+	Require(ctx context.Context, cond bool, msg string, data ...any)
+	Ensure(ctx context.Context, cond bool, msg string, data ...any)
+	Invariant(ctx context.Context, cond bool, msg string, data ...any)
+	Unreachable(ctx context.Context, msg string, data ...any) error
+	ProcessDeferredAssertions(ctx context.Context) (int, error)
+}
+
+var _ Asserter = (*AssertHandler)(nil)
@@ -0,0 +1,28 @@
+package assert
+
+import (
+	"context"
+	"testing"
+)
+
+type recordingAsserter struct {
+	Asserter
+	messages []string
+}
+
+func (r *recordingAsserter) Assert(ctx context.Context, truth bool, msg string, data ...any) {
+	if !truth {
+		r.messages = append(r.messages, msg)
+	}
+}
+
+func TestInjectedAsserter(t *testing.T) {
+	fake := &recordingAsserter{}
+	ctx := NewContext(context.TODO(), fake)
+
+	Assert(ctx, false, "Captured By Fake")
+
+	if len(fake.messages) != 1 || fake.messages[0] != "Captured By Fake" {
+		t.Fatalf("Expected the injected asserter to receive the failure, got %v", fake.messages)
+	}
+}
@@ -0,0 +1,148 @@
+// Package assertgrpc integrates assertion handlers with gRPC servers. The interceptors give
+// every RPC its own handler tagged with the full method and the peer, available to
+// package-level assertion functions through the RPC context. Failures are collected in a
+// deferred scope per RPC and turn the RPC into a codes.Internal error instead of exiting the
+// process; panics of the RPC handler are reported and answered the same way. The error names
+// the failure by its code or fingerprint only, so assertion messages and data stay in the
+// server's reports.
+//
+// The package is a separate module so the core library does not depend on gRPC.
+package assertgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Option configures the interceptors
+type Option func(*config)
+
+type config struct {
+	recordSpan func(ctx context.Context, event assert.AssertionEvent)
+}
+
+// WithSpanRecorder calls record for every failure of an RPC with the context of the
+// assertion, so it can be added to the RPC's trace span, e.g. with OpenTelemetry:
+//
+//	assertgrpc.WithSpanRecorder(func(ctx context.Context, event assert.AssertionEvent) {
+//		trace.SpanFromContext(ctx).AddEvent(event.Message, ...)
+//	})
+func WithSpanRecorder(record func(ctx context.Context, event assert.AssertionEvent)) Option {
+	return func(c *config) {
+		c.recordSpan = record
+	}
+}
+
+// UnaryServerInterceptor runs every unary RPC with a handler derived from h
+func UnaryServerInterceptor(h *assert.AssertHandler, opts ...Option) grpc.UnaryServerInterceptor {
+	cfg := newConfig(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		err = cfg.run(ctx, h, info.FullMethod, func(ctx context.Context) error {
+			resp, err = handler(ctx, req)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor runs every streaming RPC with a handler derived from h
+func StreamServerInterceptor(h *assert.AssertHandler, opts ...Option) grpc.StreamServerInterceptor {
+	cfg := newConfig(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return cfg.run(ss.Context(), h, info.FullMethod, func(ctx context.Context) error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+}
+
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// errPanicked answers RPCs whose panic was not collected as a failure, e.g. because
+// assert.KindPanic is configured to only log
+var errPanicked = errors.New("assertgrpc: handler panicked")
+
+// run calls rpc in a deferred scope of a per-RPC handler and converts the scope's
+// failures into a codes.Internal error
+func (c config) run(ctx context.Context, h *assert.AssertHandler, method string, rpc func(ctx context.Context) error) error {
+	tags := []any{"grpc_method", method}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		tags = append(tags, "peer", p.Addr.String())
+	}
+	handler := h.WithTags(tags...)
+	if c.recordSpan != nil {
+		handler = handler.With(assert.WithOnFailure(c.recordSpan))
+	}
+
+	scoped, done := handler.BeginDeferredScope(assert.NewContext(ctx, handler))
+	err := call(scoped, handler, rpc)
+	failures := handler.ScopeError(scoped)
+	done()
+
+	if failures != nil {
+		return internalError(failures)
+	}
+	if errors.Is(err, errPanicked) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return err
+}
+
+// internalError answers an RPC whose assertions failed without leaking their messages or
+// data to the client. It names the first failure by its code, or else its fingerprint, so
+// its report can be found on the server.
+func internalError(failures error) error {
+	var deferred *assert.DeferredFailures
+	if !errors.As(failures, &deferred) || len(deferred.Failures) == 0 {
+		return status.Error(codes.Internal, "assertion failed")
+	}
+	first := deferred.Failures[0]
+	ref := fmt.Sprint(first.Data["fingerprint"])
+	if code, ok := first.Data["code"]; ok {
+		ref = fmt.Sprint(code)
+	}
+	if more := len(deferred.Failures) - 1; more > 0 {
+		ref += fmt.Sprintf(" and %d more", more)
+	}
+	return status.Error(codes.Internal, "assertion failed: "+ref)
+}
+
+// call runs rpc, reporting a panic through assert.Recover
+func call(ctx context.Context, handler *assert.AssertHandler, rpc func(ctx context.Context) error) (err error) {
+	completed := false
+	defer func() {
+		if !completed {
+			err = errPanicked
+		}
+	}()
+	defer assert.Recover(ctx, handler)
+
+	err = rpc(ctx)
+	completed = true
+	return err
+}
+
+// serverStream replaces the context of a stream with the per-RPC context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,104 @@
+package assertgrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	h := assert.NewAssertHandler(assert.WithWriter(&buffer), assert.WithExitFunc(func(code int) { exits++ }))
+
+	var spans []string
+	interceptor := UnaryServerInterceptor(h, WithSpanRecorder(func(ctx context.Context, event assert.AssertionEvent) {
+		spans = append(spans, event.Message)
+	}))
+	ctx := peer.NewContext(context.TODO(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Create"}
+
+	resp, err := interceptor(ctx, "req", info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	if resp != "ok" || err != nil {
+		t.Fatalf("Expected a passing RPC to be untouched, got %v %v", resp, err)
+	}
+
+	resp, err = interceptor(ctx, "req", info, func(ctx context.Context, req any) (any, error) {
+		assert.Assert(ctx, false, "Order Invalid")
+		return "ok", nil
+	})
+	if resp != nil || status.Code(err) != codes.Internal {
+		t.Fatalf("Expected an Internal error, got %v %v", resp, err)
+	}
+	if msg := status.Convert(err).Message(); strings.Contains(msg, "Order Invalid") || !strings.Contains(buffer.String(), "fingerprint="+strings.TrimPrefix(msg, "assertion failed: ")) {
+		t.Fatalf("Expected the error to name the failure by fingerprint only, got %q", msg)
+	}
+	if !strings.Contains(buffer.String(), "grpc_method=/orders.Orders/Create") || !strings.Contains(buffer.String(), "peer=10.0.0.1:5000") {
+		t.Fatalf("Expected the RPC tags in the output, got %q", buffer.String())
+	}
+	if len(spans) != 1 || spans[0] != "Order Invalid" {
+		t.Fatalf("Expected the failure on the span, got %v", spans)
+	}
+
+	_, err = interceptor(ctx, "req", info, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal || !strings.Contains(buffer.String(), "panic=boom") {
+		t.Fatalf("Expected the panic to be reported as an Internal error, got %v", err)
+	}
+
+	notFound := status.Error(codes.NotFound, "missing")
+	if _, err = interceptor(ctx, "req", info, func(ctx context.Context, req any) (any, error) {
+		return nil, notFound
+	}); !errors.Is(err, notFound) {
+		t.Fatalf("Expected handler errors to pass through, got %v", err)
+	}
+	if exits != 0 {
+		t.Fatalf("Expected RPC failures not to exit, got %d", exits)
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	var buffer bytes.Buffer
+	h := assert.NewAssertHandler(assert.WithWriter(&buffer), assert.WithExitFunc(func(code int) {}),
+		assert.WithKindAction(assert.KindPanic, assert.ActionLog))
+	interceptor := StreamServerInterceptor(h)
+	info := &grpc.StreamServerInfo{FullMethod: "/orders.Orders/Watch"}
+
+	err := interceptor(nil, &fakeStream{ctx: context.TODO()}, info, func(srv any, ss grpc.ServerStream) error {
+		assert.Assert(ss.Context(), false, "Stream Invalid", "code", "E_STREAM")
+		assert.Assert(ss.Context(), false, "Cursor Invalid")
+		return nil
+	})
+	if status.Code(err) != codes.Internal || !strings.Contains(buffer.String(), "grpc_method=/orders.Orders/Watch") {
+		t.Fatalf("Expected an Internal error, got %v", err)
+	}
+	if msg := status.Convert(err).Message(); msg != "assertion failed: E_STREAM and 1 more" {
+		t.Fatalf("Expected the error to name the first failure by its code, got %q", msg)
+	}
+
+	err = interceptor(nil, &fakeStream{ctx: context.TODO()}, info, func(srv any, ss grpc.ServerStream) error {
+		panic("logged only")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("Expected a logged panic to fail the RPC, got %v", err)
+	}
+}
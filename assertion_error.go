@@ -0,0 +1,112 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// AssertionError describes a failed assertion. It is returned by DeferredError, ScopeError
+// and the deferred processing, and panicked by ActionPanic, strict control flow and the Must
+// and Unreachable helpers, so callers can inspect failures with errors.As.
+type AssertionError struct {
+	Msg  string
+	Data map[string]interface{}
+	// Frames is the stack starting at the failing call site
+	Frames []Frame
+	Stack  string
+	Time   time.Time
+	// Failures are the individual failures of an aggregate error of deferred assertions
+	Failures []*AssertionError
+}
+
+// Frame is one stack frame of a failure
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+func (f Frame) String() string {
+	return fmt.Sprintf("%s:%d", f.File, f.Line)
+}
+
+// WithPanicOnFailure makes fatal failures panic with their *AssertionError instead of calling
+// the exit function, once they have been reported or, in deferred mode, processed. Recover
+// sites can inspect the message, data and frames; Error gives the string form. It is
+// shorthand for WithFailurePolicy(PanicPolicy{}).
+func WithPanicOnFailure() Option {
+	return WithFailurePolicy(PanicPolicy{})
+}
+
+func (e *AssertionError) Error() string {
+	return "assertion failed: " + e.Msg
+}
+
+// Unwrap returns the error passed to NoError or recorded under the "error" key, if any,
+// followed by the individual failures of an aggregate
+func (e *AssertionError) Unwrap() []error {
+	var errs []error
+	if err, ok := e.Data["error"].(error); ok {
+		errs = append(errs, err)
+	}
+	for _, f := range e.Failures {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+// Field returns the data value recorded under key
+func (e *AssertionError) Field(key string) (any, bool) {
+	v, ok := e.Data[key]
+	return v, ok
+}
+
+// Code returns the code the failure was reported with, or "" if it has none
+func (e *AssertionError) Code() string {
+	if code, ok := e.Data["code"]; ok {
+		return fmt.Sprint(code)
+	}
+	return ""
+}
+
+// Fingerprint returns the fingerprint grouping occurrences of the failure, or "" if it has none
+func (e *AssertionError) Fingerprint() string {
+	fingerprint, _ := e.Data[fingerprintKey].(string)
+	return fingerprint
+}
+
+// Caller returns the file:line of the failing call site, or "" if no frames were captured
+func (e *AssertionError) Caller() string {
+	if len(e.Frames) == 0 {
+		return ""
+	}
+	return e.Frames[0].String()
+}
+
+// failuresKey holds the individual failures in the data of the event summarizing deferred
+// failures
+const failuresKey = "failures"
+
+func newAssertionError(event AssertionEvent) *AssertionError {
+	failures, _ := event.Data[failuresKey].([]*AssertionError)
+	return &AssertionError{
+		Msg:      event.Message,
+		Data:     event.Data,
+		Frames:   framesOf(event.Frames),
+		Stack:    event.Stack,
+		Time:     event.Time,
+		Failures: failures,
+	}
+}
+
+func framesOf(frames []runtime.Frame) []Frame {
+	if len(frames) == 0 {
+		return nil
+	}
+	out := make([]Frame, len(frames))
+	for i, f := range frames {
+		out[i] = Frame{Function: f.Function, File: f.File, Line: f.Line}
+	}
+	return out
+}
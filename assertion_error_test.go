@@ -0,0 +1,95 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAssertionErrorAccessors(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithKindAction(KindPrecondition, ActionPanic))
+
+	var err *AssertionError
+	func() {
+		defer func() { err, _ = recover().(*AssertionError) }()
+		handler.Require(context.TODO(), false, "Input Valid", "code", "E_INPUT", "user", 7, "error", os.ErrNotExist)
+	}()
+	if err == nil {
+		t.Fatalf("Expected a panic with an *AssertionError")
+	}
+
+	if err.Code() != "E_INPUT" || err.Fingerprint() == "" {
+		t.Fatalf("Expected code and fingerprint accessors, got %q and %q", err.Code(), err.Fingerprint())
+	}
+	if user, ok := err.Field("user"); !ok || user != 7 {
+		t.Fatalf("Expected the user field, got %v", user)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected the recorded error to be unwrapped")
+	}
+	if len(err.Frames) == 0 || !strings.HasSuffix(err.Frames[0].File, "assertion_error_test.go") {
+		t.Fatalf("Expected frames starting at the call site, got %v", err.Frames)
+	}
+	if !strings.Contains(err.Caller(), "assertion_error_test.go:") {
+		t.Fatalf("Expected the caller from the first frame, got %q", err.Caller())
+	}
+}
+
+func TestDeferredAggregateCarriesFailures(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithDeferMode(true), WithDeferredPolicy(DeferredNeverExit))
+
+	handler.Assert(context.TODO(), false, "First Check", "code", "E_FIRST")
+	handler.NoError(context.TODO(), os.ErrClosed, "Second Check")
+	_, err := handler.ProcessDeferredAssertions(context.TODO())
+
+	var aggregate *AssertionError
+	if !errors.As(err, &aggregate) || len(aggregate.Failures) != 2 {
+		t.Fatalf("Expected an aggregate with both failures, got %v", err)
+	}
+	if aggregate.Failures[0].Msg != "First Check" || aggregate.Failures[0].Code() != "E_FIRST" {
+		t.Fatalf("Expected the first failure to keep its message and code, got %+v", aggregate.Failures[0])
+	}
+	if !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Expected the errors of individual failures to be reachable")
+	}
+}
+
+func TestPanicOnFailure(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithPanicOnFailure())
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		handler.Assert(context.TODO(), false, "Balance Positive", "account", "acme")
+	}()
+	err, ok := recovered.(*AssertionError)
+	if !ok {
+		t.Fatalf("Expected a panic with an *AssertionError, got %T", recovered)
+	}
+	if account, _ := err.Field("account"); err.Msg != "Balance Positive" || account != "acme" || exits != 0 {
+		t.Fatalf("Expected the message and data without exiting, got %+v", err)
+	}
+	if err.Error() != "assertion failed: Balance Positive" {
+		t.Fatalf("Expected the string form from Error, got %q", err.Error())
+	}
+	if !strings.Contains(buffer.String(), "Balance Positive") {
+		t.Fatalf("Expected the failure to be reported before panicking")
+	}
+
+	deferred := handler.With(WithDeferMode(true))
+	deferred.Assert(context.TODO(), false, "Collected")
+	func() {
+		defer func() { recovered = recover() }()
+		deferred.ProcessDeferredAssertions(context.TODO())
+	}()
+	if err, ok := recovered.(*AssertionError); !ok || len(err.Failures) != 1 {
+		t.Fatalf("Expected processing to panic with the aggregate, got %v", recovered)
+	}
+}
@@ -0,0 +1,123 @@
+// Package assertmiddleware integrates assertion handlers with net/http servers. Handler
+// gives every request its own handler tagged with the method, path and request ID, available
+// to package-level assertion functions through the request context, and reports panics of
+// the wrapped handler as assertion failures.
+package assertmiddleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// Option configures Handler
+type Option func(*config)
+
+type config struct {
+	requestIDHeader string
+	checkResponse   bool
+}
+
+// WithRequestIDHeader reads the request ID from header instead of X-Request-ID. Requests
+// without the header get a random ID.
+func WithRequestIDHeader(header string) Option {
+	return func(c *config) {
+		c.requestIDHeader = header
+	}
+}
+
+// WithResponseChecks asserts that the wrapped handler writes a status or a body, and that
+// it calls WriteHeader at most once
+func WithResponseChecks() Option {
+	return func(c *config) {
+		c.checkResponse = true
+	}
+}
+
+// Handler wraps next so that each request reports through a handler derived from h. A panic
+// in next is answered with a 500 when nothing was written yet and reported through
+// assert.Recover, so the assert.KindPanic action of h decides whether the process exits.
+func Handler(next http.Handler, h *assert.AssertHandler, opts ...Option) http.Handler {
+	cfg := config{requestIDHeader: "X-Request-ID"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(cfg.requestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		handler := h.WithTags("method", r.Method, "path", r.URL.Path, "request_id", requestID)
+		ctx := assert.NewContext(r.Context(), handler)
+		rw := &responseWriter{ResponseWriter: w}
+
+		completed := false
+		defer func() {
+			if !completed && rw.status == 0 {
+				rw.ResponseWriter.WriteHeader(http.StatusInternalServerError)
+			}
+		}()
+		defer assert.Recover(ctx, handler)
+
+		next.ServeHTTP(rw, r.WithContext(ctx))
+		completed = true
+
+		if cfg.checkResponse {
+			checkResponse(ctx, handler, rw)
+		}
+	})
+}
+
+func checkResponse(ctx context.Context, handler *assert.AssertHandler, rw *responseWriter) {
+	handler.Assert(ctx, rw.status != 0, "Response Not Written", "code", "http.response_not_written")
+	handler.Assert(ctx, rw.writeHeaders <= 1, "Superfluous WriteHeader",
+		"code", "http.superfluous_write_header", "status", rw.status, "write_header_calls", rw.writeHeaders)
+}
+
+// responseWriter records what the wrapped handler wrote
+type responseWriter struct {
+	http.ResponseWriter
+	// status is the status sent, 0 until the header or the body was written
+	status       int
+	writeHeaders int
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.writeHeaders++
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush supports streaming handlers using the http.Flusher interface
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,83 @@
+package assertmiddleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+func TestHandler(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	h := assert.NewAssertHandler(assert.WithWriter(&buffer), assert.WithExitFunc(func(code int) { exits++ }))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+		assert.Assert(r.Context(), false, "Order Invalid")
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("handler exploded")
+	})
+	server := Handler(mux, h)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the handler to continue after the failure, got %d", rec.Code)
+	}
+	for _, want := range []string{"method=POST", "path=/orders", "request_id=req-1"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the output, got %q", want, buffer.String())
+		}
+	}
+
+	buffer.Reset()
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(buffer.String(), "panic=handler exploded") {
+		t.Fatalf("Expected the panic to be reported and answered with a 500, got %d %q", rec.Code, buffer.String())
+	}
+	if exits != 2 {
+		t.Fatalf("Expected both failures to run the exit function, got %d", exits)
+	}
+}
+
+func TestResponseChecks(t *testing.T) {
+	var buffer bytes.Buffer
+	h := assert.NewAssertHandler(assert.WithWriter(&buffer), assert.WithExitFunc(func(code int) {}))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/silent", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/twice", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/fine", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	server := Handler(mux, h, WithResponseChecks(), WithRequestIDHeader("X-Trace"))
+
+	for _, path := range []string{"/silent", "/twice", "/fine"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Trace", "trace"+path)
+		server.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	out := buffer.String()
+	if !strings.Contains(out, "msg=Response Not Written") || !strings.Contains(out, "request_id=trace/silent") {
+		t.Fatalf("Expected the silent handler to be reported, got %q", out)
+	}
+	if !strings.Contains(out, "msg=Superfluous WriteHeader") || !strings.Contains(out, "write_header_calls=2") {
+		t.Fatalf("Expected the second WriteHeader to be reported, got %q", out)
+	}
+	if strings.Contains(out, "path=/fine") {
+		t.Fatalf("Expected no failure for a well-behaved handler")
+	}
+}
@@ -0,0 +1,161 @@
+// Package assertqueue wraps message queue consumers so that assertion failures affect only
+// the message being handled. Each message is handled in its own deferred scope: failures
+// are reported when they happen, tagged with the message metadata, but instead of exiting
+// they fail the message, which is then acked, nacked or dead-lettered by policy. Panics
+// in the handler are reported and fail the message as well.
+//
+//	consume := assertqueue.Wrap(handle, assertqueue.Config[*kafka.Message]{
+//		Metadata:   func(m *kafka.Message) assertqueue.Metadata { ... },
+//		Ack:        commit,
+//		DeadLetter: publishToDLQ,
+//		Policy:     func(*kafka.Message, error) assertqueue.Outcome { return assertqueue.DeadLetter },
+//	})
+//
+// Inside the handler, package-level assertion functions called with the handler's context
+// report through the per-message handler.
+package assertqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// HandleFunc handles one message
+type HandleFunc[M any] func(ctx context.Context, msg M) error
+
+// Metadata identifies a message in failure reports. Zero fields are left out.
+type Metadata struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       string
+}
+
+// Outcome is how a message is settled
+type Outcome int
+
+const (
+	// Ack acknowledges the message so it is not delivered again
+	Ack Outcome = iota
+	// Nack rejects the message so it is delivered again
+	Nack
+	// DeadLetter hands the message to the dead-letter callback
+	DeadLetter
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Ack:
+		return "ack"
+	case Nack:
+		return "nack"
+	case DeadLetter:
+		return "dead-letter"
+	default:
+		return fmt.Sprintf("Outcome(%d)", int(o))
+	}
+}
+
+// Config configures Wrap. Zero values use defaults.
+type Config[M any] struct {
+	// Handler reports the failures, assert.DefaultHandler() if nil
+	Handler *assert.AssertHandler
+	// Metadata extracts the metadata attached to the failures of a message
+	Metadata func(msg M) Metadata
+	// Policy decides how a failed message is settled, Nack if nil. Messages handled
+	// without failure are acked.
+	Policy func(msg M, err error) Outcome
+	// Ack, Nack and DeadLetter settle a message; nil callbacks do nothing
+	Ack        func(ctx context.Context, msg M) error
+	Nack       func(ctx context.Context, msg M, err error) error
+	DeadLetter func(ctx context.Context, msg M, err error) error
+}
+
+// Wrap returns a HandleFunc running handle under a deferred scope per message. The message
+// fails when handle returns an error, reports assertion failures or panics. The returned
+// function settles the message and returns the failure, joined with any settling error.
+func Wrap[M any](handle HandleFunc[M], cfg Config[M]) HandleFunc[M] {
+	return func(ctx context.Context, msg M) error {
+		handler := cfg.Handler
+		if handler == nil {
+			handler = assert.DefaultHandler()
+		}
+		if cfg.Metadata != nil {
+			handler = handler.WithTags(cfg.Metadata(msg).tags()...)
+		}
+
+		scoped, done := handler.BeginDeferredScope(assert.NewContext(ctx, handler))
+		err := run(scoped, handler, handle, msg)
+		if failures := handler.ScopeError(scoped); failures != nil {
+			// a reported panic is already among the failures
+			if errors.Is(failures, err) {
+				err = failures
+			} else {
+				err = errors.Join(err, failures)
+			}
+		}
+		done()
+
+		if err == nil {
+			return settle(ctx, cfg.Ack, msg)
+		}
+		outcome := Nack
+		if cfg.Policy != nil {
+			outcome = cfg.Policy(msg, err)
+		}
+		switch outcome {
+		case Ack:
+			return errors.Join(err, settle(ctx, cfg.Ack, msg))
+		case DeadLetter:
+			return errors.Join(err, settleFailed(ctx, cfg.DeadLetter, msg, err))
+		default:
+			return errors.Join(err, settleFailed(ctx, cfg.Nack, msg, err))
+		}
+	}
+}
+
+// run calls handle, reporting a panic as an assertion failure of the message
+func run[M any](ctx context.Context, handler *assert.AssertHandler, handle HandleFunc[M], msg M) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("assertqueue: handler panicked: %v", r)
+			handler.NoError(ctx, err, "Message Handler Panicked", "panic", r, "panic_stack", string(debug.Stack()))
+		}
+	}()
+	return handle(ctx, msg)
+}
+
+func settle[M any](ctx context.Context, fn func(context.Context, M) error, msg M) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, msg)
+}
+
+func settleFailed[M any](ctx context.Context, fn func(context.Context, M, error) error, msg M, err error) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, msg, err)
+}
+
+func (m Metadata) tags() []any {
+	var kv []any
+	if m.Topic != "" {
+		kv = append(kv, "topic", m.Topic)
+	}
+	if m.Partition != 0 {
+		kv = append(kv, "partition", m.Partition)
+	}
+	if m.Offset != 0 {
+		kv = append(kv, "offset", m.Offset)
+	}
+	if m.Key != "" {
+		kv = append(kv, "key", m.Key)
+	}
+	return kv
+}
@@ -0,0 +1,89 @@
+package assertqueue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+type message struct {
+	offset int64
+	body   string
+}
+
+func TestWrap(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := assert.NewAssertHandler(assert.WithWriter(&buffer), assert.WithExitFunc(func(code int) { exits++ }))
+
+	var settled []string
+	record := func(outcome Outcome) func(ctx context.Context, msg message, err error) error {
+		return func(ctx context.Context, msg message, err error) error {
+			settled = append(settled, outcome.String()+":"+msg.body)
+			return nil
+		}
+	}
+	consume := Wrap(func(ctx context.Context, msg message) error {
+		switch msg.body {
+		case "invalid":
+			assert.Assert(ctx, false, "Invalid Message")
+			assert.Assert(ctx, false, "Still Invalid")
+		case "panic":
+			panic("boom")
+		case "error":
+			return errors.New("temporary")
+		}
+		return nil
+	}, Config[message]{
+		Handler:  handler,
+		Metadata: func(msg message) Metadata { return Metadata{Topic: "orders", Offset: msg.offset} },
+		Policy: func(msg message, err error) Outcome {
+			var failures *assert.DeferredFailures
+			if errors.As(err, &failures) {
+				return DeadLetter
+			}
+			return Nack
+		},
+		Ack: func(ctx context.Context, msg message) error {
+			settled = append(settled, "ack:"+msg.body)
+			return nil
+		},
+		Nack:       record(Nack),
+		DeadLetter: record(DeadLetter),
+	})
+
+	if err := consume(context.TODO(), message{1, "valid"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err := consume(context.TODO(), message{2, "invalid"})
+	var failures *assert.DeferredFailures
+	if !errors.As(err, &failures) || len(failures.Failures) != 2 {
+		t.Fatalf("Expected both failures of the message, got %v", err)
+	}
+	if failures.Failures[0].Data["topic"] != "orders" || failures.Failures[0].Data["offset"] != int64(2) {
+		t.Fatalf("Expected the message metadata on the failure, got %v", failures.Failures[0].Data)
+	}
+	if !strings.Contains(buffer.String(), "topic=orders") {
+		t.Fatalf("Expected the failures to be reported with metadata, got %q", buffer.String())
+	}
+
+	err = consume(context.TODO(), message{3, "panic"})
+	if !errors.As(err, &failures) || len(failures.Failures) != 1 || failures.Failures[0].Data["panic"] != "boom" {
+		t.Fatalf("Expected the panic as a single failure, got %v", err)
+	}
+	if err := consume(context.TODO(), message{4, "error"}); err == nil || err.Error() != "temporary" {
+		t.Fatalf("Expected the handler error, got %v", err)
+	}
+
+	expected := "ack:valid dead-letter:invalid dead-letter:panic nack:error"
+	if got := strings.Join(settled, " "); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+	if exits != 0 {
+		t.Fatalf("Expected failed messages not to exit, got %d exits", exits)
+	}
+}
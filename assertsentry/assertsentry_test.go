@@ -0,0 +1,108 @@
+package assertsentry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+type capturerFunc func(ctx context.Context, event *Event) error
+
+func (f capturerFunc) CaptureEvent(ctx context.Context, event *Event) error { return f(ctx, event) }
+
+func TestHook(t *testing.T) {
+	var events []*Event
+	capture := capturerFunc(func(ctx context.Context, event *Event) error {
+		events = append(events, event)
+		return nil
+	})
+	handler := assert.NewAssertHandler(assert.WithWriter(io.Discard), assert.WithExitFunc(func(code int) {}),
+		assert.WithOnFailure(Hook(capture)))
+
+	handler.Assert(context.TODO(), false, "Cache Miss", "code", "CACHE-1", "key", "user:1", "ch", make(chan int))
+	handler.Assert(context.TODO(), false, "Uncoded")
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	event := events[0]
+	if event.Level != "error" || event.Message != "Cache Miss" || event.Tags["code"] != "CACHE-1" || event.Extra["key"] != "user:1" {
+		t.Fatalf("Unexpected event %+v", event)
+	}
+	if _, ok := event.Extra["ch"].(string); !ok {
+		t.Fatalf("Expected unencodable values to be rendered, got %T", event.Extra["ch"])
+	}
+	if len(event.Fingerprint) != 2 || event.Fingerprint[1] != event.Tags["fingerprint"] || event.Exception[0].Type != "CACHE-1" {
+		t.Fatalf("Expected grouping by the handler's fingerprint, got %v", event.Fingerprint)
+	}
+	if !strings.HasSuffix(event.Culprit, ".TestHook") {
+		t.Fatalf("Expected the test as culprit, got %q", event.Culprit)
+	}
+	frames := event.Exception[0].Stacktrace.Frames
+	last := frames[len(frames)-1]
+	if last.Function != "TestHook" || !last.InApp || last.Filename != "assertsentry/assertsentry_test.go" || frames[0].InApp {
+		t.Fatalf("Expected frames oldest first ending in the test, got %+v", frames)
+	}
+
+	if fp := events[1].Fingerprint; len(fp) != 2 || fp[1] == event.Fingerprint[1] {
+		t.Fatalf("Expected a separate group for another failure, got %v", fp)
+	}
+	if fp := NewEvent(assert.AssertionEvent{Message: "Raw", Data: map[string]interface{}{"code": "C"}}).Fingerprint; strings.Join(fp, ",") != "assert,C" {
+		t.Fatalf("Expected grouping by code without a handler fingerprint, got %v", fp)
+	}
+}
+
+func TestSplitFunction(t *testing.T) {
+	cases := map[string][2]string{
+		"github.com/a/b.(*T).M": {"github.com/a/b", "(*T).M"},
+		"main.main.func1":       {"main", "main.func1"},
+		"runtime.goexit":        {"runtime", "goexit"},
+	}
+	for name, want := range cases {
+		if module, function := splitFunction(name); module != want[0] || function != want[1] {
+			t.Fatalf("splitFunction(%q) = %q, %q", name, module, function)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	var auth, path string
+	var lines [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("X-Sentry-Auth"), r.URL.Path
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, bytes.Clone(scanner.Bytes()))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(strings.Replace(server.URL, "://", "://public@", 1) + "/sentry/42")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	event := NewEvent(assert.AssertionEvent{Severity: assert.SeverityWarn, Message: "Slow"})
+	if err := client.CaptureEvent(context.TODO(), event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if path != "/sentry/api/42/envelope/" || !strings.Contains(auth, "sentry_key=public") {
+		t.Fatalf("Unexpected request to %s with auth %q", path, auth)
+	}
+	var sent Event
+	if len(lines) != 3 || json.Unmarshal(lines[2], &sent) != nil || sent.EventID != event.EventID || sent.Level != "warning" {
+		t.Fatalf("Unexpected envelope %q", lines)
+	}
+
+	if _, err := NewClient("https://sentry.example.com/42"); err == nil {
+		t.Fatalf("Expected an error for a DSN without key")
+	}
+}
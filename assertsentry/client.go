@@ -0,0 +1,126 @@
+package assertsentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// Capturer sends events to an error tracker. Client implements it; an adapter around an
+// existing Sentry SDK hub can implement it as well.
+type Capturer interface {
+	CaptureEvent(ctx context.Context, event *Event) error
+}
+
+// Hook returns an assert.FailureHook sending every failure to c. The hook runs synchronously
+// on the failing goroutine, so c should bound how long it blocks. Errors are printed to
+// os.Stderr since the failure itself was already reported.
+func Hook(c Capturer) assert.FailureHook {
+	return func(ctx context.Context, event assert.AssertionEvent) {
+		// the failure is sent even if the assertion's context is already canceled
+		ctx = context.WithoutCancel(ctx)
+		if err := c.CaptureEvent(ctx, NewEvent(event)); err != nil {
+			fmt.Fprintf(os.Stderr, "Sentry error: %v\n", err)
+		}
+	}
+}
+
+// Client posts events to the envelope endpoint of the project named by a Sentry DSN
+type Client struct {
+	// HTTPClient sends the requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+	// Timeout bounds each request (default 5s)
+	Timeout time.Duration
+
+	dsn      string
+	endpoint string
+	auth     string
+}
+
+// NewClient returns a Client for a DSN of the form scheme://key@host[/path]/project
+func NewClient(dsn string) (*Client, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("assertsentry: invalid DSN: %w", err)
+	}
+	path, project := "", strings.TrimPrefix(u.Path, "/")
+	if i := strings.LastIndex(project, "/"); i >= 0 {
+		path, project = "/"+project[:i], project[i+1:]
+	}
+	if u.User == nil || u.User.Username() == "" || u.Host == "" || project == "" {
+		return nil, fmt.Errorf("assertsentry: invalid DSN %q: want scheme://key@host/project", dsn)
+	}
+
+	return &Client{
+		dsn:      dsn,
+		endpoint: fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path, project),
+		auth:     fmt.Sprintf("Sentry sentry_version=7, sentry_client=assertsentry/1.0, sentry_key=%s", u.User.Username()),
+	}, nil
+}
+
+func (c *Client) CaptureEvent(ctx context.Context, event *Event) error {
+	body, err := c.envelope(event)
+	if err != nil {
+		return err
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", c.auth)
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("assertsentry: %s returned %s", c.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// envelope renders the event as a single-item Sentry envelope
+func (c *Client) envelope(event *Event) ([]byte, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	header, _ := json.Marshal(map[string]string{
+		"event_id": event.EventID,
+		"dsn":      c.dsn,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339),
+	})
+	item, _ := json.Marshal(map[string]interface{}{"type": "event", "length": len(payload)})
+
+	var b bytes.Buffer
+	for _, line := range [][]byte{header, item, payload} {
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,215 @@
+// Package assertsentry reports failed assertions to Sentry, or any error tracker speaking
+// the Sentry protocol, from an assert.WithOnFailure hook:
+//
+//	client, err := assertsentry.NewClient(os.Getenv("SENTRY_DSN"))
+//	...
+//	handler := assert.NewAssertHandler(assert.WithOnFailure(assertsentry.Hook(client)))
+//
+// Events carry the message, the data as extra fields, the parsed stack frames and the
+// calling function as culprit. Failures are grouped by the handler's fingerprint, the same
+// one the other sinks receive. The package has no dependencies; to report through an existing
+// Sentry SDK instead, implement Capturer on top of it.
+package assertsentry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// Event is the subset of the Sentry event payload filled from an assertion event
+type Event struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Platform    string                 `json:"platform"`
+	Logger      string                 `json:"logger"`
+	Message     string                 `json:"message"`
+	Culprit     string                 `json:"culprit,omitempty"`
+	Fingerprint []string               `json:"fingerprint,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Exception   []Exception            `json:"exception,omitempty"`
+}
+
+// Exception is a Sentry exception with its stack trace
+type Exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Module     string      `json:"module,omitempty"`
+	Stacktrace *Stacktrace `json:"stacktrace,omitempty"`
+}
+
+// Stacktrace lists frames oldest first, as Sentry expects
+type Stacktrace struct {
+	Frames []Frame `json:"frames"`
+}
+
+// Frame is a Sentry stack frame
+type Frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+// tagKeys are the data keys promoted to searchable tags
+var tagKeys = []string{"code", "area"}
+
+// NewEvent converts a failed assertion into a Sentry event
+func NewEvent(event assert.AssertionEvent) *Event {
+	frames := NewFrames(event.Frames)
+	out := &Event{
+		EventID:   eventID(),
+		Timestamp: event.Time,
+		Level:     level(event.Severity),
+		Platform:  "go",
+		Logger:    "assert",
+		Message:   event.Message,
+		Tags:      map[string]string{"severity": event.Severity.String()},
+		Extra:     extra(event.Data),
+	}
+	if out.Timestamp.IsZero() {
+		out.Timestamp = time.Now()
+	}
+
+	// the most recent in-app frame is the culprit
+	for i := len(frames) - 1; i >= 0; i-- {
+		if frames[i].InApp {
+			out.Culprit = qualified(frames[i])
+			break
+		}
+	}
+
+	exception := Exception{Type: "AssertionError", Value: event.Message}
+	for _, key := range tagKeys {
+		if v, ok := event.Data[key]; ok {
+			out.Tags[key] = fmt.Sprint(v)
+		}
+	}
+	code, hasCode := out.Tags["code"]
+	if hasCode {
+		exception.Type = code
+	}
+	// the handler's fingerprint keeps the grouping consistent with the other sinks
+	switch {
+	case event.Fingerprint != "":
+		out.Fingerprint = []string{"assert", event.Fingerprint}
+		out.Tags["fingerprint"] = event.Fingerprint
+	case hasCode:
+		out.Fingerprint = []string{"assert", code}
+	default:
+		out.Fingerprint = []string{"assert", out.Culprit, event.Message}
+	}
+	if len(frames) > 0 {
+		exception.Module = frames[len(frames)-1].Module
+		exception.Stacktrace = &Stacktrace{Frames: frames}
+	}
+	out.Exception = []Exception{exception}
+	return out
+}
+
+// NewFrames converts frames ordered innermost first, as captured by the handler, into
+// Sentry frames ordered oldest first
+func NewFrames(frames []runtime.Frame) []Frame {
+	out := make([]Frame, 0, len(frames))
+	for i := len(frames) - 1; i >= 0; i-- {
+		f := frames[i]
+		module, function := splitFunction(f.Function)
+		out = append(out, Frame{
+			Function: function,
+			Module:   module,
+			Filename: trimFilename(f.File, module),
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    inApp(module, f.File),
+		})
+	}
+	return out
+}
+
+// splitFunction splits "github.com/a/b.(*T).M" into "github.com/a/b" and "(*T).M"
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	dot += slash + 1
+	return name[:dot], name[dot+1:]
+}
+
+// trimFilename shortens an absolute path to the part below the package directory
+func trimFilename(file, module string) string {
+	pkg := module
+	if i := strings.LastIndex(module, "/"); i >= 0 {
+		pkg = module[i+1:]
+	}
+	if i := strings.LastIndex(file, "/"+pkg+"/"); i >= 0 {
+		return file[i+1:]
+	}
+	return file
+}
+
+// libraryModule prefixes the assertion library's packages
+const libraryModule = "github.com/ZanzyTHEbar/assert-lib"
+
+// inApp reports whether frames of module belong to the application, as opposed to the
+// standard library or the assertion library, whose tests count as application code.
+// Standard library packages have no dot in their first path element; main is the exception.
+func inApp(module, file string) bool {
+	if module == "main" {
+		return true
+	}
+	if module == libraryModule || strings.HasPrefix(module, libraryModule+"/") {
+		return strings.HasSuffix(file, "_test.go")
+	}
+	first, _, _ := strings.Cut(module, "/")
+	return strings.Contains(first, ".")
+}
+
+func qualified(f Frame) string {
+	if f.Module == "" {
+		return f.Function
+	}
+	return f.Module + "." + f.Function
+}
+
+func level(s assert.Severity) string {
+	switch s {
+	case assert.SeverityInfo:
+		return "info"
+	case assert.SeverityWarn:
+		return "warning"
+	case assert.SeverityFatal:
+		return "fatal"
+	default:
+		return "error"
+	}
+}
+
+// extra copies the data, rendering values JSON can't encode with fmt
+func extra(data map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprintf("%+v", v)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func eventID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
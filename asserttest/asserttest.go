@@ -0,0 +1,47 @@
+// Package asserttest provides helpers for testing code that uses assert, such as running
+// an assertion's real exit path in a child process.
+package asserttest
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// testFatalEnv tells a re-executed test binary which test should run the fatal function
+const testFatalEnv = "ASSERT_LIB_TEST_FATAL"
+
+// Fatal re-executes the current test binary running only t's test, where fn is called
+// with the real exit behavior. It returns the child's combined output and exit code, so the
+// os.Exit path of an assertion can be verified. fn must be deterministic across runs.
+func Fatal(t testing.TB, fn func()) (output string, exitCode int) {
+	t.Helper()
+
+	if os.Getenv(testFatalEnv) == t.Name() {
+		fn()
+		os.Exit(0)
+	}
+
+	parts := strings.Split(t.Name(), "/")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run="+strings.Join(parts, "/"), "-test.count=1")
+	cmd.Env = append(os.Environ(), testFatalEnv+"="+t.Name())
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return string(out), 0
+	case errors.As(err, &exitErr):
+		return string(out), exitErr.ExitCode()
+	default:
+		t.Fatalf("asserttest: failed to re-execute test binary: %v", err)
+		return "", -1
+	}
+}
@@ -0,0 +1,23 @@
+package asserttest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+func TestFatalSubprocess(t *testing.T) {
+	output, code := Fatal(t, func() {
+		handler := assert.NewAssertHandler()
+		handler.Assert(context.TODO(), false, "Subprocess Fatal")
+	})
+
+	if code != 1 {
+		t.Fatalf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(output, "Subprocess Fatal") {
+		t.Fatalf("Expected failure message in child output, got %s", output)
+	}
+}
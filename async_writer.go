@@ -0,0 +1,123 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrAsyncWriterClosed is returned by Flush after the AsyncWriter was closed
+var ErrAsyncWriterClosed = errors.New("assert: async writer closed")
+
+// asyncItem is either data to write or a flush marker closed once everything before it is written
+type asyncItem struct {
+	data    []byte
+	flushed chan struct{}
+}
+
+// AsyncWriter queues writes to a background goroutine so assertions in latency-sensitive
+// paths don't block on slow writers such as network sinks or files on NFS. Writes never
+// block: when the bounded queue is full they are dropped and counted. Handlers flush their
+// writers before exiting, so use it as the handler's writer:
+//
+//	handler := assert.NewAssertHandler(assert.WithWriter(assert.NewAsyncWriter(conn, 1024)))
+type AsyncWriter struct {
+	w       io.Writer
+	queue   chan asyncItem
+	mu      sync.RWMutex
+	closed  bool
+	done    chan struct{}
+	dropped atomic.Int64
+	err     atomic.Value
+}
+
+// NewAsyncWriter returns an AsyncWriter writing to w with room for size queued writes
+func NewAsyncWriter(w io.Writer, size int) *AsyncWriter {
+	aw := &AsyncWriter{
+		w:     w,
+		queue: make(chan asyncItem, size),
+		done:  make(chan struct{}),
+	}
+	go aw.run()
+	return aw
+}
+
+func (aw *AsyncWriter) run() {
+	defer close(aw.done)
+	for item := range aw.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		if _, err := aw.w.Write(item.data); err != nil {
+			aw.err.Store(err)
+		}
+	}
+}
+
+// Write queues a copy of p. It reports success even if p is dropped because the queue is full.
+func (aw *AsyncWriter) Write(p []byte) (int, error) {
+	aw.mu.RLock()
+	defer aw.mu.RUnlock()
+	if aw.closed {
+		aw.dropped.Add(1)
+		return len(p), nil
+	}
+
+	select {
+	case aw.queue <- asyncItem{data: append([]byte(nil), p...)}:
+	default:
+		aw.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Flush waits until every write queued before the call reached the underlying writer
+func (aw *AsyncWriter) Flush(ctx context.Context) error {
+	aw.mu.RLock()
+	if aw.closed {
+		aw.mu.RUnlock()
+		return ErrAsyncWriterClosed
+	}
+	flushed := make(chan struct{})
+	select {
+	case aw.queue <- asyncItem{flushed: flushed}:
+		aw.mu.RUnlock()
+	case <-ctx.Done():
+		aw.mu.RUnlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return aw.LastError()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close writes everything still queued and stops the background goroutine
+func (aw *AsyncWriter) Close() error {
+	aw.mu.Lock()
+	if !aw.closed {
+		aw.closed = true
+		close(aw.queue)
+	}
+	aw.mu.Unlock()
+
+	<-aw.done
+	return aw.LastError()
+}
+
+// Dropped returns how many writes were dropped because the queue was full or closed
+func (aw *AsyncWriter) Dropped() int64 {
+	return aw.dropped.Load()
+}
+
+// LastError returns the most recent error of the underlying writer, if any
+func (aw *AsyncWriter) LastError() error {
+	err, _ := aw.err.Load().(error)
+	return err
+}
@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowWriter delays every write to simulate a network sink
+type slowWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(50 * time.Millisecond)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *slowWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncWriterFlushesBeforeExit(t *testing.T) {
+	slow := &slowWriter{}
+	async := NewAsyncWriter(slow, 16)
+	defer async.Close()
+
+	var atExit string
+	handler := NewAssertHandler(WithWriter(async), WithExitFunc(func(code int) { atExit = slow.String() }))
+
+	start := time.Now()
+	handler.With(WithErrorSeverityMapper(func(error) Severity { return SeverityWarn })).NoError(context.TODO(), context.Canceled, "Queued Warning")
+	if time.Since(start) > 40*time.Millisecond {
+		t.Fatalf("Expected the assertion not to block on the slow writer")
+	}
+
+	handler.Assert(context.TODO(), false, "Fatal Failure")
+	if !strings.Contains(atExit, "msg=Queued Warning") || !strings.Contains(atExit, "msg=Fatal Failure") {
+		t.Fatalf("Expected all output to be flushed before the exit function, got %q", atExit)
+	}
+}
+
+func TestAsyncWriterDropsWhenFull(t *testing.T) {
+	slow := &slowWriter{}
+	async := NewAsyncWriter(slow, 1)
+
+	for i := 0; i < 10; i++ {
+		async.Write([]byte("line\n"))
+	}
+	if async.Dropped() == 0 {
+		t.Fatalf("Expected writes beyond the queue size to be dropped")
+	}
+	if err := async.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := async.Flush(context.TODO()); err != ErrAsyncWriterClosed {
+		t.Fatalf("Expected Flush after Close to fail, got %v", err)
+	}
+	if got := strings.Count(slow.String(), "line\n"); int64(got)+async.Dropped() != 10 {
+		t.Fatalf("Expected every write to be either written or dropped, got %d written", got)
+	}
+}
@@ -0,0 +1,248 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBatcherClosed is returned when exporting to a batcher that has been shut down
+var ErrBatcherClosed = errors.New("assert: batcher is shut down")
+
+// BatcherConfig controls batching, retry and memory bounds. Zero values use defaults.
+type BatcherConfig struct {
+	MaxBatchSize   int           // events per Export call (default 100)
+	MaxDelay       time.Duration // max time an event waits before being sent (default 1s)
+	MaxQueue       int           // max buffered events; newer events are dropped beyond this (default 1000)
+	MaxRetries     int           // retries per batch after the first attempt (default 3, negative disables retries)
+	InitialBackoff time.Duration // first retry delay, doubled on every retry (default 100ms)
+	MaxBackoff     time.Duration // upper bound for the retry delay (default 5s)
+}
+
+// BatcherStats reports what happened to events passing through a Batcher
+type BatcherStats struct {
+	Queued   int    // events currently buffered
+	Exported uint64 // events accepted by the wrapped exporter
+	Dropped  uint64 // events rejected because the queue was full
+	Failed   uint64 // events given up on after exhausting retries
+	Retries  uint64 // retried Export calls
+}
+
+// Batcher is a backpressure-aware Exporter that buffers events in bounded memory and
+// forwards them in batches to the wrapped exporter, retrying with exponential backoff.
+// Network sinks should be wrapped in a Batcher rather than buffering on their own.
+type Batcher struct {
+	next Exporter
+	cfg  BatcherConfig
+
+	mu     sync.Mutex
+	queue  []AssertionEvent
+	closed bool
+
+	kick chan struct{}
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	// sending is the context of background sends, canceled by Shutdown so it does not wait
+	// out a retry backoff
+	sending    context.Context
+	cancelSend context.CancelFunc
+
+	exported atomic.Uint64
+	dropped  atomic.Uint64
+	failed   atomic.Uint64
+	retries  atomic.Uint64
+}
+
+// NewBatcher wraps next and starts the background sender
+func NewBatcher(next Exporter, cfg BatcherConfig) *Batcher {
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = 100
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = time.Second
+	}
+	if cfg.MaxQueue <= 0 {
+		cfg.MaxQueue = 1000
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = 100 * time.Millisecond
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Second
+	}
+
+	b := &Batcher{
+		next: next,
+		cfg:  cfg,
+		kick: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+	b.sending, b.cancelSend = context.WithCancel(context.Background())
+	b.wg.Add(1)
+	go b.loop()
+	return b
+}
+
+// Export queues events without blocking on the wrapped exporter.
+// Events that do not fit in the queue are dropped and counted in Stats.
+func (b *Batcher) Export(ctx context.Context, events []AssertionEvent) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	room := b.cfg.MaxQueue - len(b.queue)
+	if room < 0 {
+		room = 0
+	}
+	accepted := events
+	if len(events) > room {
+		accepted = events[:room]
+		b.dropped.Add(uint64(len(events) - room))
+	}
+	b.queue = append(b.queue, accepted...)
+	full := len(b.queue) >= b.cfg.MaxBatchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.kick <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Flush synchronously sends everything currently buffered
+func (b *Batcher) Flush(ctx context.Context) error {
+	var errs []error
+	for {
+		batch := b.take()
+		if len(batch) == 0 {
+			return errors.Join(errs...)
+		}
+		if err := b.send(ctx, batch); err != nil {
+			b.failed.Add(uint64(len(batch)))
+			errs = append(errs, err)
+		}
+	}
+}
+
+// Shutdown stops the background sender, flushes the queue and shuts down the wrapped exporter.
+// A batch the sender was sending or waiting to retry goes back to the queue and is flushed
+// with ctx, so shutting down is bounded by ctx rather than the retry backoff.
+func (b *Batcher) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.stop)
+	b.cancelSend()
+	b.wg.Wait()
+
+	return errors.Join(b.Flush(ctx), b.next.Shutdown(ctx))
+}
+
+// Stats returns a snapshot of the batcher counters. They count events rather than
+// assertions, so they are kept here instead of in the Stats of the handler exporting to b.
+func (b *Batcher) Stats() BatcherStats {
+	b.mu.Lock()
+	queued := len(b.queue)
+	b.mu.Unlock()
+
+	return BatcherStats{
+		Queued:   queued,
+		Exported: b.exported.Load(),
+		Dropped:  b.dropped.Load(),
+		Failed:   b.failed.Load(),
+		Retries:  b.retries.Load(),
+	}
+}
+
+func (b *Batcher) loop() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.cfg.MaxDelay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+		case <-b.kick:
+		}
+		for batch := b.take(); len(batch) > 0; batch = b.take() {
+			if err := b.send(b.sending, batch); err != nil {
+				if b.sending.Err() != nil {
+					b.requeue(batch)
+					return
+				}
+				b.failed.Add(uint64(len(batch)))
+			}
+		}
+	}
+}
+
+func (b *Batcher) take() []AssertionEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(b.queue)
+	if n > b.cfg.MaxBatchSize {
+		n = b.cfg.MaxBatchSize
+	}
+	if n == 0 {
+		return nil
+	}
+	batch := append([]AssertionEvent(nil), b.queue[:n]...)
+	b.queue = b.queue[n:]
+	return batch
+}
+
+// requeue puts back a batch whose send Shutdown interrupted, ahead of the newer events
+func (b *Batcher) requeue(batch []AssertionEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queue = append(batch, b.queue...)
+}
+
+// send exports batch, retrying with backoff until it is accepted, the retries run out or
+// ctx is done
+func (b *Batcher) send(ctx context.Context, batch []AssertionEvent) error {
+	backoff := b.cfg.InitialBackoff
+	var err error
+	for attempt := 0; attempt <= b.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			b.retries.Add(1)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+			backoff *= 2
+			if backoff > b.cfg.MaxBackoff {
+				backoff = b.cfg.MaxBackoff
+			}
+		}
+		if err = b.next.Export(ctx, batch); err == nil {
+			b.exported.Add(uint64(len(batch)))
+			return nil
+		}
+	}
+	return err
+}
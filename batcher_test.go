@@ -0,0 +1,99 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingExporter struct {
+	mu      sync.Mutex
+	batches [][]AssertionEvent
+	fail    int
+}
+
+func (c *countingExporter) Export(ctx context.Context, events []AssertionEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fail > 0 {
+		c.fail--
+		return errors.New("unavailable")
+	}
+	c.batches = append(c.batches, events)
+	return nil
+}
+
+func (c *countingExporter) Shutdown(ctx context.Context) error { return nil }
+
+func TestBatcherGroupsAndDrops(t *testing.T) {
+	next := &countingExporter{}
+	batcher := NewBatcher(next, BatcherConfig{MaxBatchSize: 2, MaxQueue: 3, MaxDelay: time.Hour})
+
+	events := []AssertionEvent{{Message: "a"}, {Message: "b"}, {Message: "c"}, {Message: "d"}}
+	if err := batcher.Export(context.TODO(), events); err != nil {
+		t.Fatalf("Unexpected export error: %v", err)
+	}
+	if err := batcher.Shutdown(context.TODO()); err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+
+	stats := batcher.Stats()
+	if stats.Dropped != 1 || stats.Exported != 3 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+	if len(next.batches) != 2 || len(next.batches[0]) != 2 {
+		t.Fatalf("Expected batches of at most two events, got %v", next.batches)
+	}
+}
+
+func TestBatcherRetriesWithBackoff(t *testing.T) {
+	next := &countingExporter{fail: 2}
+	batcher := NewBatcher(next, BatcherConfig{MaxDelay: time.Hour, InitialBackoff: time.Millisecond})
+
+	batcher.Export(context.TODO(), []AssertionEvent{{Message: "a"}})
+	if err := batcher.Flush(context.TODO()); err != nil {
+		t.Fatalf("Expected flush to succeed after retries: %v", err)
+	}
+	batcher.Shutdown(context.TODO())
+
+	stats := batcher.Stats()
+	if stats.Retries != 2 || stats.Exported != 1 || stats.Failed != 0 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
+
+// unavailableExporter fails every export, signalling the first attempt on attempted
+type unavailableExporter struct {
+	attempted chan struct{}
+	once      sync.Once
+}
+
+func (u *unavailableExporter) Export(ctx context.Context, events []AssertionEvent) error {
+	u.once.Do(func() { close(u.attempted) })
+	return errors.New("unavailable")
+}
+
+func (u *unavailableExporter) Shutdown(ctx context.Context) error { return nil }
+
+func TestBatcherShutdownInterruptsBackoff(t *testing.T) {
+	next := &unavailableExporter{attempted: make(chan struct{})}
+	batcher := NewBatcher(next, BatcherConfig{MaxBatchSize: 1, InitialBackoff: time.Hour, MaxBackoff: time.Hour})
+
+	batcher.Export(context.TODO(), []AssertionEvent{{Message: "a"}})
+	<-next.attempted
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := batcher.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the flush to give up when ctx is done, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Expected Shutdown not to wait out the backoff, took %v", elapsed)
+	}
+	if stats := batcher.Stats(); stats.Failed != 1 || stats.Queued != 0 {
+		t.Fatalf("Expected the interrupted batch to be retried once and counted as failed, got %+v", stats)
+	}
+}
@@ -0,0 +1,114 @@
+package assert
+
+import (
+	"strings"
+	"time"
+)
+
+// collectionSkippedKey lists the collectors a failure was reported without
+const collectionSkippedKey = "collection_skipped"
+
+// WithCollectionBudget bounds how long dumping AssertData, running field providers,
+// capturing the stack and formatting may take for one failure, e.g. 50ms on latency
+// sensitive paths. Collectors still running when the budget is spent are abandoned and
+// named in the comma separated "collection_skipped" field; if formatting is abandoned or
+// no time is left for it, a truncated event with only the core fields is reported instead.
+func WithCollectionBudget(d time.Duration) Option {
+	return func(a *AssertHandler) {
+		a.collectionBudget = d
+	}
+}
+
+// collectionBudget tracks the time left to collect one failure
+type collectionBudget struct {
+	deadline time.Time
+	skipped  []string
+}
+
+// newCollectionBudget starts the budget of a failure, nil without WithCollectionBudget.
+// Callers must hold flushLock.
+func (a *AssertHandler) newCollectionBudget() *collectionBudget {
+	if a.collectionBudget <= 0 {
+		return nil
+	}
+	return &collectionBudget{deadline: time.Now().Add(a.collectionBudget)}
+}
+
+// run calls fn and reports whether it completed within the budget. Without a budget fn runs
+// inline; otherwise it runs in its own goroutine, which is left behind on timeout, so fn
+// must only publish its result through variables read after run returned true.
+func (b *collectionBudget) run(name string, fn func()) bool {
+	if b == nil {
+		fn()
+		return true
+	}
+	remaining := time.Until(b.deadline)
+	if remaining <= 0 {
+		b.skipped = append(b.skipped, name)
+		return false
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		b.skipped = append(b.skipped, name)
+		return false
+	}
+}
+
+// snapshot returns the data a collector may read, a copy when the collector can outlive run
+func (b *collectionBudget) snapshot(data map[string]interface{}) map[string]interface{} {
+	if b == nil {
+		return data
+	}
+	copied := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		copied[k] = v
+	}
+	return copied
+}
+
+// formatWithin formats event within the budget, replacing it with a truncated event when
+// formatting is abandoned. Callers must hold flushLock.
+func (a *AssertHandler) formatWithin(budget *collectionBudget, event AssertionEvent) (AssertionEvent, string) {
+	if budget == nil {
+		return event, a.formatLocal(event)
+	}
+	if len(budget.skipped) > 0 {
+		event.Data[collectionSkippedKey] = strings.Join(budget.skipped, ",")
+	}
+
+	var formatted string
+	snapshot := event
+	snapshot.Data = budget.snapshot(event.Data)
+	if budget.run("format", func() { formatted = a.formatLocal(snapshot) }) {
+		return event, formatted
+	}
+
+	event = truncatedEvent(event, budget.skipped)
+	// the fast formatter cannot fail and takes no noticeable time
+	out, _ := (&FastFormatter{}).FormatEvent(event)
+	return event, string(out)
+}
+
+// truncatedEvent keeps the fields identifying a failure and notes the skipped collectors
+func truncatedEvent(event AssertionEvent, skipped []string) AssertionEvent {
+	data := map[string]interface{}{collectionSkippedKey: strings.Join(skipped, ",")}
+	for _, key := range []string{"msg", "area", "severity", "caller", "code", fingerprintKey} {
+		if v, ok := event.Data[key]; ok {
+			data[key] = v
+		}
+	}
+	event.Data = data
+	event.Stack = ""
+	event.Frames = nil
+	return event
+}
@@ -0,0 +1,100 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowData blocks Dump until release is closed
+type slowData struct {
+	release chan struct{}
+}
+
+func (d slowData) Dump() string {
+	<-d.release
+	return "late"
+}
+
+// slowFormatter blocks formatting until release is closed
+type slowFormatter struct {
+	release chan struct{}
+}
+
+func (f slowFormatter) FormatEvent(event AssertionEvent) ([]byte, error) {
+	<-f.release
+	return []byte(event.Message), nil
+}
+
+func TestCollectionBudgetSkipsSlowCollectors(t *testing.T) {
+	var buffer bytes.Buffer
+	release := make(chan struct{})
+	defer close(release)
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithCollectionBudget(20*time.Millisecond))
+	handler.AddAssertData("slow", slowData{release: release})
+	handler.AddFieldProvider(FieldProviderFunc(func(ctx context.Context, event AssertionEvent) []KV {
+		return []KV{{Key: "trace_id", Value: "abc"}}
+	}))
+
+	start := time.Now()
+	handler.Assert(context.TODO(), false, "Budget Spent")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Expected the slow collector to be abandoned, took %v", elapsed)
+	}
+	for _, want := range []string{`msg="Budget Spent"`, "collection_skipped=assert_data.slow,field_provider.0,stack,format"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the failure, got %q", want, buffer.String())
+		}
+	}
+	if strings.Contains(buffer.String(), "late") {
+		t.Fatalf("Expected the abandoned dump to be left out, got %q", buffer.String())
+	}
+}
+
+func TestCollectionBudgetTruncatesSlowFormatting(t *testing.T) {
+	var buffer bytes.Buffer
+	release := make(chan struct{})
+	defer close(release)
+	var exported AssertionEvent
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) {}),
+		WithEventFormatter(slowFormatter{release: release}),
+		WithCollectionBudget(20*time.Millisecond),
+		WithOnFailure(func(ctx context.Context, event AssertionEvent) { exported = event }),
+	)
+
+	handler.Assert(context.TODO(), false, "Slow Format", "code", "billing.slow", "payload", "large")
+	if !strings.Contains(buffer.String(), `msg="Slow Format" code=billing.slow`) || !strings.Contains(buffer.String(), "collection_skipped=format") {
+		t.Fatalf("Expected a truncated event, got %q", buffer.String())
+	}
+	if _, ok := exported.Data["payload"]; ok || exported.Data["code"] != "billing.slow" {
+		t.Fatalf("Expected the truncated event to be reported to hooks, got %v", exported.Data)
+	}
+}
+
+func TestCollectionBudgetKeepsFastCollectors(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithCollectionBudget(time.Second))
+	handler.AddAssertData("fast", staticData("ready"))
+
+	handler.Assert(context.TODO(), false, "Within Budget")
+	if !strings.Contains(buffer.String(), "ready") || strings.Contains(buffer.String(), collectionSkippedKey) {
+		t.Fatalf("Expected the full event, got %q", buffer.String())
+	}
+}
+
+func TestCollectionBudgetKeepsCallerStack(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithCollectionBudget(time.Second))
+
+	handler.Assert(context.TODO(), false, "Stack Under Budget")
+	if !strings.Contains(buffer.String(), "TestCollectionBudgetKeepsCallerStack") {
+		t.Fatalf("Expected the stack of the calling goroutine, got %q", buffer.String())
+	}
+	if strings.Contains(buffer.String(), "collectionBudget).run") {
+		t.Fatalf("Expected no budget goroutine frames in the stack, got %q", buffer.String())
+	}
+}
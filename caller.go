@@ -0,0 +1,69 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// modulePath prefixes every function of this module, including its sub-packages
+const modulePath = "github.com/ZanzyTHEbar/assert-lib"
+
+// callSite returns the first stack frame outside this module's non-test code, i.e. the
+// code that called into the assertion library
+func callSite() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isLibraryFrame(frame) {
+			return frame, frame.PC != 0
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+func isLibraryFrame(frame runtime.Frame) bool {
+	fn := frame.Function
+	if !strings.HasPrefix(fn, modulePath) {
+		return false
+	}
+	rest := fn[len(modulePath):]
+	if !strings.HasPrefix(rest, ".") && !strings.HasPrefix(rest, "/") {
+		return false
+	}
+	return !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// callSiteLocation renders the call site as file:line
+func callSiteLocation() string {
+	frame, ok := callSite()
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+}
+
+// maxFrames bounds how many frames are captured for an event
+const maxFrames = 64
+
+// callerFrames returns the stack starting at the call site, without this module's frames on top
+func callerFrames() []runtime.Frame {
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var out []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		if len(out) > 0 || !isLibraryFrame(frame) {
+			out = append(out, frame)
+		}
+		if !more {
+			return out
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errChannelClosed is the failure of ReceivesWithin on a closed channel
+var errChannelClosed = errors.New("channel closed")
+
+// ReceivesWithin waits up to timeout for a value from ch and returns it. It fails when ch is
+// closed, the timeout elapses or ctx is done first, returning the zero value and false. It
+// reports through the handler on ctx or the default handler.
+func ReceivesWithin[T any](ctx context.Context, ch <-chan T, timeout time.Duration, msg string, data ...any) (T, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case v, ok := <-ch:
+		if ok {
+			passedFor(ctx, KindAssert)
+			return v, true
+		}
+		err = errChannelClosed
+	case <-timer.C:
+		err = errors.New("timed out")
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	handlerFor(ctx).Assert(ctx, false, msg, append(data, "timeout", timeout, "error", err)...)
+	var zero T
+	return zero, false
+}
+
+// Closed fails when ch is not closed. It does not wait: an open channel without a pending
+// value fails, and a pending value is consumed and added to the failure data.
+func Closed[T any](ctx context.Context, ch <-chan T, msg string, data ...any) {
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			passedFor(ctx, KindAssert)
+			return
+		}
+		data = append(data, "error", "channel open", "value", v)
+	default:
+		data = append(data, "error", "channel open")
+	}
+	handlerFor(ctx).Assert(ctx, false, msg, data...)
+}
+
+// NoReceiveWithin fails when a value is received from ch, or ch is closed, within d. It
+// returns early without failing when ctx is done.
+func NoReceiveWithin[T any](ctx context.Context, ch <-chan T, d time.Duration, msg string, data ...any) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		data = append(data, "within", d)
+		if ok {
+			data = append(data, "value", v)
+		} else {
+			data = append(data, "error", errChannelClosed)
+		}
+		handlerFor(ctx).Assert(ctx, false, msg, data...)
+	case <-timer.C:
+		passedFor(ctx, KindAssert)
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChannelAssertions(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+
+	ch := make(chan int, 1)
+	ch <- 7
+	if v, ok := ReceivesWithin(ctx, ch, time.Second, "No Value"); !ok || v != 7 {
+		t.Fatalf("Expected to receive 7, got %d", v)
+	}
+	NoReceiveWithin(ctx, ch, 10*time.Millisecond, "Unexpected Value")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	if _, ok := ReceivesWithin(ctx, ch, 10*time.Millisecond, "No Value"); ok || !strings.Contains(buffer.String(), "error=timed out") {
+		t.Fatalf("Expected a timeout failure, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	canceled, cancel := context.WithCancelCause(ctx)
+	cancel(nil)
+	start := time.Now()
+	ReceivesWithin(canceled, ch, time.Minute, "No Value")
+	NoReceiveWithin(canceled, ch, time.Minute, "Unexpected Value")
+	if time.Since(start) > 10*time.Second {
+		t.Fatalf("Expected a done context to stop waiting")
+	}
+
+	buffer.Reset()
+	Closed(ctx, ch, "Still Open")
+	if !strings.Contains(buffer.String(), "error=channel open") {
+		t.Fatalf("Expected an open channel to fail, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	ch <- 8
+	NoReceiveWithin(ctx, ch, time.Second, "Unexpected Value")
+	if !strings.Contains(buffer.String(), "value=8") {
+		t.Fatalf("Expected the received value, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	close(ch)
+	Closed(ctx, ch, "Still Open")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected a closed channel to pass, got %q", buffer.String())
+	}
+	if _, ok := ReceivesWithin(ctx, ch, time.Second, "No Value"); ok || !strings.Contains(buffer.String(), "error=channel closed") {
+		t.Fatalf("Expected a closed channel to fail, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,22 @@
+package assert
+
+// With returns a child handler inheriting a's formatter, writer, flushes, exporters and tags,
+// with opts applied on top. Deferred failures of the child are processed together with a's.
+func (a *AssertHandler) With(opts ...Option) *AssertHandler {
+	a.flushLock.Lock()
+	child := a.clone()
+	a.flushLock.Unlock()
+
+	child.apply(opts)
+	return child
+}
+
+// WithTags returns a child handler that adds the key/value pairs to every failure it reports
+func (a *AssertHandler) WithTags(kv ...any) *AssertHandler {
+	a.flushLock.Lock()
+	child := a.clone()
+	a.flushLock.Unlock()
+
+	child.tags = append(child.tags, kv...)
+	return child
+}
@@ -0,0 +1,27 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestChildHandlerInheritsAndTags(t *testing.T) {
+	var parentBuffer, childBuffer bytes.Buffer
+	parent := NewAssertHandler(WithWriter(&parentBuffer), WithExitFunc(func(code int) {}), WithFormatter(&JSONFormatter{}))
+
+	child := parent.WithTags("component", "cache").With(WithWriter(&childBuffer))
+	child.Assert(context.TODO(), false, "Child Failure")
+
+	if parentBuffer.Len() != 0 {
+		t.Fatalf("Expected child writer override to leave the parent writer untouched")
+	}
+	if !bytes.Contains(childBuffer.Bytes(), []byte(`"component": "cache"`)) {
+		t.Fatalf("Expected inherited JSON formatter and tag in output, got %s", childBuffer.String())
+	}
+
+	parent.Assert(context.TODO(), false, "Parent Failure")
+	if bytes.Contains(parentBuffer.Bytes(), []byte("component")) {
+		t.Fatalf("Expected child tags not to leak into the parent")
+	}
+}
@@ -0,0 +1,100 @@
+package assert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Verbosity controls how much detail a formatter includes
+type Verbosity int
+
+const (
+	// VerbosityQuiet shows only the severity and message
+	VerbosityQuiet Verbosity = iota
+	// VerbosityNormal adds the caller and key data on the same line
+	VerbosityNormal
+	// VerbosityVerbose expands every data field on its own line and appends the stack
+	VerbosityVerbose
+)
+
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiBlue   = "\033[34m"
+	ansiGray   = "\033[90m"
+)
+
+// cliReservedKeys are rendered in fixed positions rather than as inline data
+var cliReservedKeys = map[string]bool{"msg": true, "area": true, "severity": true, "caller": true}
+
+// CLIFormatter renders one compact line per failure for command-line tools, e.g.
+//
+//	ERROR config file missing (main.go:42) path=/etc/app.yaml
+type CLIFormatter struct {
+	Verbosity Verbosity
+	NoColor   bool
+}
+
+func (f *CLIFormatter) Format(assertData map[string]interface{}, stack string) string {
+	severity := fmt.Sprint(assertData["severity"])
+	if severity == "<nil>" {
+		severity = SeverityError.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(f.paint(severityColor(severity)+ansiBold, severity))
+	b.WriteString(" ")
+	b.WriteString(fmt.Sprint(assertData["msg"]))
+
+	if f.Verbosity == VerbosityQuiet {
+		return b.String()
+	}
+
+	if caller, ok := assertData["caller"]; ok {
+		b.WriteString(" ")
+		b.WriteString(f.paint(ansiGray, fmt.Sprintf("(%v)", caller)))
+	}
+
+	keys := make([]string, 0, len(assertData))
+	for k := range assertData {
+		if !cliReservedKeys[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if f.Verbosity == VerbosityVerbose {
+			fmt.Fprintf(&b, "\n    %s=%s", f.paint(ansiBlue, k), renderValue(assertData[k]))
+		} else {
+			fmt.Fprintf(&b, " %s=%s", f.paint(ansiBlue, k), renderValue(assertData[k]))
+		}
+	}
+
+	if f.Verbosity == VerbosityVerbose && stack != "" {
+		b.WriteString("\n")
+		b.WriteString(f.paint(ansiGray, strings.TrimRight(stack, "\n")))
+	}
+	return b.String()
+}
+
+func (f *CLIFormatter) paint(color, s string) string {
+	if f.NoColor {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+func severityColor(severity string) string {
+	switch severity {
+	case "FATAL", "ERROR":
+		return ansiRed
+	case "WARN":
+		return ansiYellow
+	default:
+		return ansiBlue
+	}
+}
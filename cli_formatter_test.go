@@ -0,0 +1,43 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCLIFormatter(t *testing.T) {
+	data := map[string]interface{}{"msg": "Config Missing", "severity": "ERROR", "caller": "main.go:42", "path": "/etc/app.yaml", "area": "Assert"}
+
+	quiet := (&CLIFormatter{Verbosity: VerbosityQuiet, NoColor: true}).Format(data, "stack")
+	if quiet != "ERROR Config Missing" {
+		t.Fatalf("Unexpected quiet output: %q", quiet)
+	}
+
+	normal := (&CLIFormatter{Verbosity: VerbosityNormal, NoColor: true}).Format(data, "stack")
+	if normal != "ERROR Config Missing (main.go:42) path=/etc/app.yaml" {
+		t.Fatalf("Unexpected normal output: %q", normal)
+	}
+
+	verbose := (&CLIFormatter{Verbosity: VerbosityVerbose, NoColor: true}).Format(data, "stack")
+	if !strings.Contains(verbose, "\n    path=/etc/app.yaml") || !strings.HasSuffix(verbose, "stack") {
+		t.Fatalf("Unexpected verbose output: %q", verbose)
+	}
+
+	colored := (&CLIFormatter{}).Format(data, "")
+	if !strings.HasPrefix(colored, ansiRed) {
+		t.Fatalf("Expected severity-colored prefix, got %q", colored)
+	}
+}
+
+func TestCallerRecorded(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	handler.Assert(context.TODO(), false, "Caller Check")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("cli_formatter_test.go:")) {
+		t.Fatalf("Expected the test file as caller, got %s", buffer.String())
+	}
+}
@@ -0,0 +1,138 @@
+// Package collectors provides ready-made assert.AssertData implementations that add the
+// state of common runtime objects to every failure:
+//
+//	collectors.Register(handler)
+//	handler.AddAssertData("db", collectors.DBStats(db))
+//	reqHandler := collectors.WithRequest(handler, r)
+//
+// Every collector reads its object when a failure is reported, not when it is registered.
+package collectors
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// Register adds the Process and RuntimeMetrics collectors to h under "process" and "runtime"
+func Register(h *assert.AssertHandler) {
+	h.AddAssertData("process", Process())
+	h.AddAssertData("runtime", RuntimeMetrics())
+}
+
+// WithRequest returns a child of h whose failures include the summary of r under "request"
+func WithRequest(h *assert.AssertHandler, r *http.Request) *assert.AssertHandler {
+	child := h.With()
+	child.AddAssertData("request", Request(r))
+	return child
+}
+
+// redactedHeaders are summarized without their values
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Proxy-Authorization": true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// Request summarizes r: method, URL without credentials, protocol, remote address, content
+// length and headers, with the values of credential headers redacted
+func Request(r *http.Request) assert.AssertData {
+	return assert.AssertDataFunc(func() string {
+		u := *r.URL
+		u.User = nil
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s %s %s host=%s remote=%s content_length=%d", r.Method, u.String(), r.Proto, r.Host, r.RemoteAddr, r.ContentLength)
+		names := make([]string, 0, len(r.Header))
+		for name := range r.Header {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := strings.Join(r.Header[name], ",")
+			if redactedHeaders[name] {
+				value = "[redacted]"
+			}
+			fmt.Fprintf(&b, " %s=%q", name, value)
+		}
+		return b.String()
+	})
+}
+
+// DBStats dumps the connection pool statistics of db
+func DBStats(db *sql.DB) assert.AssertData {
+	return assert.AssertDataFunc(func() string {
+		s := db.Stats()
+		return fmt.Sprintf("open=%d in_use=%d idle=%d max_open=%d wait_count=%d wait_duration=%s max_idle_closed=%d max_idle_time_closed=%d max_lifetime_closed=%d",
+			s.OpenConnections, s.InUse, s.Idle, s.MaxOpenConnections, s.WaitCount, s.WaitDuration,
+			s.MaxIdleClosed, s.MaxIdleTimeClosed, s.MaxLifetimeClosed)
+	})
+}
+
+// DefaultMetrics are the runtime/metrics samples RuntimeMetrics reads without arguments
+var DefaultMetrics = []string{
+	"/sched/goroutines:goroutines",
+	"/sched/gomaxprocs:threads",
+	"/memory/classes/total:bytes",
+	"/memory/classes/heap/objects:bytes",
+	"/gc/heap/goal:bytes",
+	"/gc/cycles/total:gc-cycles",
+}
+
+// RuntimeMetrics snapshots the runtime/metrics samples with the given names, DefaultMetrics
+// if none. Names the runtime doesn't support are left out; histograms are summarized by
+// their sample count.
+func RuntimeMetrics(names ...string) assert.AssertData {
+	if len(names) == 0 {
+		names = DefaultMetrics
+	}
+	return assert.AssertDataFunc(func() string {
+		samples := make([]metrics.Sample, len(names))
+		for i, name := range names {
+			samples[i].Name = name
+		}
+		metrics.Read(samples)
+
+		parts := make([]string, 0, len(samples))
+		for _, s := range samples {
+			switch s.Value.Kind() {
+			case metrics.KindUint64:
+				parts = append(parts, fmt.Sprintf("%s=%d", s.Name, s.Value.Uint64()))
+			case metrics.KindFloat64:
+				parts = append(parts, fmt.Sprintf("%s=%g", s.Name, s.Value.Float64()))
+			case metrics.KindFloat64Histogram:
+				var count uint64
+				for _, c := range s.Value.Float64Histogram().Counts {
+					count += c
+				}
+				parts = append(parts, fmt.Sprintf("%s=%d samples", s.Name, count))
+			}
+		}
+		return strings.Join(parts, " ")
+	})
+}
+
+// started approximates the process start time
+var started = time.Now()
+
+// Process describes the current process: pid, parent pid, executable, arguments, working
+// directory, uptime and Go runtime
+func Process() assert.AssertData {
+	return assert.AssertDataFunc(func() string {
+		executable, _ := os.Executable()
+		wd, _ := os.Getwd()
+		return fmt.Sprintf("pid=%d ppid=%d executable=%s args=%q wd=%s uptime=%s go=%s goroutines=%d gomaxprocs=%d",
+			os.Getpid(), os.Getppid(), executable, os.Args[1:], wd, time.Since(started).Round(time.Millisecond),
+			runtime.Version(), runtime.NumGoroutine(), runtime.GOMAXPROCS(0))
+	})
+}
@@ -0,0 +1,81 @@
+package assert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const ansiGreen = "\033[32m"
+
+// expectedKeys and actualKeys are highlighted so the two sides of a comparison stand out
+var (
+	expectedKeys = map[string]bool{"expected": true, "want": true}
+	actualKeys   = map[string]bool{"actual": true, "got": true}
+)
+
+// ColorTextFormatter renders the TextFormatter layout with ANSI colors: the message in bold
+// red, expected and actual values in green and red, and stack frames with the function
+// names emphasized. Colors are only emitted when Color is set.
+type ColorTextFormatter struct {
+	Color bool
+	// KeyOrder lists keys to write first, in this order, instead of msg
+	KeyOrder []string
+}
+
+// NewColorTextFormatter returns a ColorTextFormatter with colors enabled when w is a terminal
+// and the NO_COLOR environment variable is not set
+func NewColorTextFormatter(w io.Writer) *ColorTextFormatter {
+	return &ColorTextFormatter{Color: colorEnabled(w)}
+}
+
+// colorEnabled follows the NO_COLOR convention (https://no-color.org) and only enables
+// colors for character devices
+func colorEnabled(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (f *ColorTextFormatter) Format(assertData map[string]interface{}, stack string) string {
+	var b strings.Builder
+	b.WriteString("ASSERT\n")
+	for _, k := range orderedKeys(assertData, f.KeyOrder) {
+		value := renderValue(assertData[k])
+		switch {
+		case k == "msg":
+			fmt.Fprintf(&b, "   msg=%s\n", f.paint(ansiRed+ansiBold, value))
+			continue
+		case expectedKeys[k]:
+			value = f.paint(ansiGreen, value)
+		case actualKeys[k]:
+			value = f.paint(ansiRed, value)
+		}
+		fmt.Fprintf(&b, "   %s=%s\n", f.paint(ansiBlue, k), value)
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(stack, "\n"), "\n") {
+		// frames alternate between the function and an indented file:line
+		if strings.HasPrefix(line, "\t") {
+			b.WriteString(f.paint(ansiGray, line))
+		} else {
+			b.WriteString(f.paint(ansiBold, line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (f *ColorTextFormatter) paint(color, s string) string {
+	if !f.Color {
+		return s
+	}
+	return color + s + ansiReset
+}
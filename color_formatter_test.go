@@ -0,0 +1,39 @@
+package assert
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestColorTextFormatter(t *testing.T) {
+	data := map[string]interface{}{"msg": "Mismatch", "expected": 1, "actual": 2}
+	stack := "main.run()\n\t/app/main.go:12 +0x1d\n"
+
+	colored := (&ColorTextFormatter{Color: true}).Format(data, stack)
+	for _, expected := range []string{
+		"msg=" + ansiRed + ansiBold + "Mismatch" + ansiReset,
+		ansiGreen + "1" + ansiReset,
+		ansiRed + "2" + ansiReset,
+		ansiBold + "main.run()" + ansiReset,
+		ansiGray + "\t/app/main.go:12 +0x1d" + ansiReset,
+	} {
+		if !strings.Contains(colored, expected) {
+			t.Fatalf("Expected %q in colored output, got %q", expected, colored)
+		}
+	}
+
+	plain := (&ColorTextFormatter{}).Format(data, stack)
+	if strings.Contains(plain, "\033[") || !strings.Contains(plain, "   actual=2\n") {
+		t.Fatalf("Expected plain output without colors, got %q", plain)
+	}
+
+	if NewColorTextFormatter(&bytes.Buffer{}).Color {
+		t.Fatalf("Expected colors to be disabled for non-terminal writers")
+	}
+	t.Setenv("NO_COLOR", "")
+	if NewColorTextFormatter(os.Stderr).Color {
+		t.Fatalf("Expected NO_COLOR to disable colors")
+	}
+}
@@ -0,0 +1,260 @@
+// Package testify is a compatibility shim exposing testify-style assertion objects backed
+// by an assert-lib handler and its formatters, to ease migrating existing test suites.
+//
+//	is := testify.New(t)      // like testify's assert.New(t): failures mark the test failed
+//	must := testify.NewRequire(t) // like testify's require.New(t): failures stop the test
+package testify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// Assertions mirrors the method set of testify's *assert.Assertions for the most common checks
+type Assertions struct {
+	t       testing.TB
+	handler *assert.AssertHandler
+}
+
+// New returns assertions that mark t as failed and keep running, like testify's assert.New
+func New(t testing.TB, opts ...assert.Option) *Assertions {
+	return newAssertions(t, t.Fail, opts)
+}
+
+// NewRequire returns assertions that stop the test on failure, like testify's require.New
+func NewRequire(t testing.TB, opts ...assert.Option) *Assertions {
+	return newAssertions(t, t.FailNow, opts)
+}
+
+func newAssertions(t testing.TB, fail func(), opts []assert.Option) *Assertions {
+	base := []assert.Option{
+		assert.WithWriter(testWriter{t}),
+		assert.WithExitFunc(func(int) { fail() }),
+	}
+	return &Assertions{
+		t:       t,
+		handler: assert.NewIsolatedHandler(append(base, opts...)...),
+	}
+}
+
+// Handler returns the underlying handler, e.g. to register AssertData
+func (a *Assertions) Handler() *assert.AssertHandler {
+	return a.handler
+}
+
+type testWriter struct {
+	t testing.TB
+}
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Helper()
+	w.t.Log(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+func (a *Assertions) check(ok bool, defaultMsg string, msgAndArgs []any, data ...any) bool {
+	a.t.Helper()
+	if !ok {
+		a.handler.Assert(context.Background(), false, message(defaultMsg, msgAndArgs), data...)
+	}
+	return ok
+}
+
+// message renders testify-style msgAndArgs: a single value, or a format string and its args
+func message(defaultMsg string, msgAndArgs []any) string {
+	switch len(msgAndArgs) {
+	case 0:
+		return defaultMsg
+	case 1:
+		return fmt.Sprint(msgAndArgs[0])
+	default:
+		if format, ok := msgAndArgs[0].(string); ok {
+			return fmt.Sprintf(format, msgAndArgs[1:]...)
+		}
+		return fmt.Sprint(msgAndArgs...)
+	}
+}
+
+// ObjectsAreEqual reports whether expected and actual are equal, comparing []byte by content
+func ObjectsAreEqual(expected, actual any) bool {
+	if expected == nil || actual == nil {
+		return expected == actual
+	}
+	exp, ok := expected.([]byte)
+	if !ok {
+		return reflect.DeepEqual(expected, actual)
+	}
+	act, ok := actual.([]byte)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(exp, act)
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return true
+		}
+		return isEmpty(rv.Elem().Interface())
+	default:
+		return rv.IsZero()
+	}
+}
+
+// contains reports whether s contains element, for strings, slices, arrays and map keys
+func contains(s, element any) (found, ok bool) {
+	rv := reflect.ValueOf(s)
+	switch rv.Kind() {
+	case reflect.String:
+		e, isString := element.(string)
+		return isString && strings.Contains(rv.String(), e), isString
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			if ObjectsAreEqual(key.Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if ObjectsAreEqual(rv.Index(i).Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// True asserts that value is true
+func (a *Assertions) True(value bool, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(value, "Should be true", msgAndArgs)
+}
+
+// False asserts that value is false
+func (a *Assertions) False(value bool, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(!value, "Should be false", msgAndArgs)
+}
+
+// Equal asserts that expected and actual are equal
+func (a *Assertions) Equal(expected, actual any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	if ObjectsAreEqual(expected, actual) {
+		return true
+	}
+	data := []any{"expected", expected, "actual", actual}
+	if diff := assert.Diff(expected, actual); diff != "" {
+		data = append(data, "diff", diff)
+	}
+	return a.check(false, "Not equal", msgAndArgs, data...)
+}
+
+// NotEqual asserts that expected and actual are not equal
+func (a *Assertions) NotEqual(expected, actual any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(!ObjectsAreEqual(expected, actual), "Should not be equal", msgAndArgs, "expected", expected, "actual", actual)
+}
+
+// Nil asserts that object is nil
+func (a *Assertions) Nil(object any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(isNil(object), "Expected nil", msgAndArgs, "actual", object)
+}
+
+// NotNil asserts that object is not nil
+func (a *Assertions) NotNil(object any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(!isNil(object), "Expected value not to be nil", msgAndArgs)
+}
+
+// NoError asserts that err is nil
+func (a *Assertions) NoError(err error, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(err == nil, "Received unexpected error", msgAndArgs, "error", err)
+}
+
+// Error asserts that err is not nil
+func (a *Assertions) Error(err error, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(err != nil, "An error is expected but got nil", msgAndArgs)
+}
+
+// ErrorIs asserts that errors.Is(err, target)
+func (a *Assertions) ErrorIs(err, target error, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(errors.Is(err, target), "Target error should be in err chain", msgAndArgs, "error", err, "target", target)
+}
+
+// Empty asserts that object is empty (zero value, or zero length)
+func (a *Assertions) Empty(object any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(isEmpty(object), "Should be empty", msgAndArgs, "actual", object)
+}
+
+// NotEmpty asserts that object is not empty
+func (a *Assertions) NotEmpty(object any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	return a.check(!isEmpty(object), "Should not be empty", msgAndArgs, "actual", object)
+}
+
+// Len asserts that object has the given length
+func (a *Assertions) Len(object any, length int, msgAndArgs ...any) bool {
+	a.t.Helper()
+	rv := reflect.ValueOf(object)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return a.check(rv.Len() == length, "Unexpected length", msgAndArgs, "expected", length, "actual", rv.Len())
+	default:
+		return a.check(false, "Object has no length", msgAndArgs, "actual", object)
+	}
+}
+
+// Contains asserts that s (a string, slice, array or map) contains element
+func (a *Assertions) Contains(s, element any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	found, ok := contains(s, element)
+	if !ok {
+		return a.check(false, "Could not apply Contains", msgAndArgs, "container", s, "element", element)
+	}
+	return a.check(found, "Does not contain element", msgAndArgs, "container", s, "element", element)
+}
+
+// NotContains asserts that s (a string, slice, array or map) does not contain element
+func (a *Assertions) NotContains(s, element any, msgAndArgs ...any) bool {
+	a.t.Helper()
+	found, ok := contains(s, element)
+	if !ok {
+		return a.check(false, "Could not apply NotContains", msgAndArgs, "container", s, "element", element)
+	}
+	return a.check(!found, "Should not contain element", msgAndArgs, "container", s, "element", element)
+}
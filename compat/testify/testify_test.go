@@ -0,0 +1,69 @@
+package testify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeT records failures instead of failing the real test
+type fakeT struct {
+	testing.TB
+	logs    []string
+	failed  bool
+	stopped bool
+}
+
+func (f *fakeT) Helper()         {}
+func (f *fakeT) Log(args ...any) { f.logs = append(f.logs, args[0].(string)) }
+func (f *fakeT) Fail()           { f.failed = true }
+func (f *fakeT) FailNow()        { f.failed, f.stopped = true, true }
+
+func TestAssertionsPassAndFail(t *testing.T) {
+	ft := &fakeT{}
+	is := New(ft)
+
+	if !is.Equal([]byte("a"), []byte("a")) || !is.Contains([]string{"x", "y"}, "y") || !is.NoError(nil) {
+		t.Fatalf("Expected passing checks to return true")
+	}
+	if ft.failed {
+		t.Fatalf("Expected no failure for passing checks")
+	}
+
+	if is.Equal(1, 2, "values differ for %s", "ids") {
+		t.Fatalf("Expected failing check to return false")
+	}
+	if !ft.failed || ft.stopped {
+		t.Fatalf("Expected New to mark the test failed without stopping it")
+	}
+
+	found := false
+	for _, line := range ft.logs {
+		found = found || strings.Contains(line, "values differ for ids")
+	}
+	if !found {
+		t.Fatalf("Expected formatted message in test log, got %v", ft.logs)
+	}
+}
+
+func TestRequireStops(t *testing.T) {
+	ft := &fakeT{}
+	must := NewRequire(ft)
+
+	must.ErrorIs(errors.New("other"), errors.ErrUnsupported)
+
+	if !ft.stopped {
+		t.Fatalf("Expected NewRequire to stop the test")
+	}
+}
+
+func TestEqualShowsDiff(t *testing.T) {
+	ft := &fakeT{}
+	is := New(ft)
+
+	is.Equal("line one\nline two", "line one\nline 2")
+
+	if log := strings.Join(ft.logs, "\n"); !strings.Contains(log, "-line two\n+line 2") {
+		t.Fatalf("Expected a line diff in the test log, got %s", log)
+	}
+}
@@ -0,0 +1,76 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+)
+
+type countingFlush struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (c *countingFlush) Flush() {
+	c.mu.Lock()
+	c.count++
+	c.mu.Unlock()
+}
+
+// Run with -race to verify the handler's locking
+func TestConcurrentHandlerUse(t *testing.T) {
+	handler := NewAssertHandler(WithWriter(io.Discard), WithExitFunc(func(code int) {}))
+
+	const workers = 16
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("worker/%d", i)
+			handler.AddAssertData(key, staticData(key))
+			handler.AddAssertFlush(&countingFlush{})
+			handler.SetDeferAssertions(i%2 == 0)
+			handler.Assert(context.TODO(), false, "Concurrent Failure", "worker", i)
+			handler.NoError(context.TODO(), fmt.Errorf("worker %d", i), "Concurrent Error")
+			handler.RemoveAssertData(key)
+			handler.SetFormatter(&TextFormatter{})
+			handler.ProcessDeferredAssertions(context.TODO())
+		}(i)
+	}
+	wg.Wait()
+
+	var buffer bytes.Buffer
+	handler.ToWriter(&buffer)
+	handler.SetDeferAssertions(false)
+	handler.Assert(context.TODO(), false, "After Concurrency")
+	if !bytes.Contains(buffer.Bytes(), []byte("After Concurrency")) {
+		t.Fatalf("Expected handler to remain usable after concurrent use")
+	}
+}
+
+// Run with -race to verify that reconfiguring the default handler does not race with its
+// assertions
+func TestConcurrentConfigure(t *testing.T) {
+	defer SetDefaultHandler(nil)
+	SetDefaultHandler(NewAssertHandler(WithWriter(io.Discard), WithExitFunc(func(code int) {})))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			Configure(WithStats(), WithReportFirst(2), WithDeferUntil(3), WithMaxDeferred(4))
+		}()
+		go func(i int) {
+			defer wg.Done()
+			Assert(context.TODO(), true, "Concurrent Pass")
+			Assert(context.TODO(), false, "Concurrent Failure", "worker", i)
+			Pass(context.TODO(), "CONCURRENT", "Concurrent Pass")
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,117 @@
+// Package concurrencytest codifies the concurrency guarantees of assert handlers as
+// executable checks. Run it with the race detector:
+//
+//	go test -race ./...
+//
+// The guarantees are:
+//   - every exported method of *assert.AssertHandler may be called concurrently with any other,
+//     including configuration methods such as AddAssertData, SetFormatter and ToWriter
+//   - handlers derived with With and WithTags may be used concurrently with their parent
+//   - failures deferred concurrently are each processed exactly once, by exactly one of
+//     ProcessDeferredAssertions, DeferredError or ClearDeferred
+//   - formatters, flushers and data dumps are called with the handler's lock held, so they
+//     need not be safe for concurrent use by a single handler
+package concurrencytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// Workers is how many goroutines Hammer runs
+const Workers = 16
+
+// Rounds is how many times each Hammer goroutine repeats its calls
+const Rounds = 20
+
+type staticData string
+
+func (s staticData) Dump() string { return string(s) }
+
+type flush struct{}
+
+func (flush) Flush() {}
+
+type discardExporter struct{}
+
+func (discardExporter) Export(ctx context.Context, events []assert.AssertionEvent) error { return nil }
+func (discardExporter) Shutdown(ctx context.Context) error                               { return nil }
+
+// Hammer calls every feature of h from many goroutines at once and then checks that h is
+// still usable and lost no deferred failures. It reconfigures h: output goes to io.Discard,
+// the exit function does nothing and deferred mode ends up off, so pass a dedicated handler.
+func Hammer(t testing.TB, h *assert.AssertHandler) {
+	t.Helper()
+	ctx := context.Background()
+	h.ToWriter(io.Discard)
+	h.SetExitFunc(func(int) {})
+
+	var wg sync.WaitGroup
+	for w := 0; w < Workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			key := fmt.Sprintf("hammer/%d", w)
+			child := h.With().WithTags("worker", w)
+
+			for r := 0; r < Rounds; r++ {
+				h.AddAssertData(key, staticData(key))
+				h.AddAssertFlush(flush{})
+				h.SetDeferAssertions((w+r)%2 == 0)
+				h.SetFormatter(&assert.TextFormatter{})
+
+				h.Assert(ctx, false, "Hammer Assert", "worker", w, "round", r)
+				h.NoError(ctx, errors.New("hammer"), "Hammer NoError")
+				h.Nil(ctx, w, "Hammer Nil")
+				h.Equal(ctx, w, r, "Hammer Equal")
+				h.Pass(ctx, "HAMMER", "Hammer Pass")
+				child.Assert(ctx, false, "Hammer Child", "code", "HAMMER")
+				h.Group("hammer").Assert(ctx, false, "Hammer Group")
+
+				h.DeferredCount()
+				h.DeferredEvents()
+				h.Taxonomy()
+				h.History("HAMMER")
+
+				switch r % 3 {
+				case 0:
+					h.ProcessDeferredAssertions(ctx)
+				case 1:
+					h.DeferredError()
+				case 2:
+					h.ClearDeferred()
+				}
+				h.RemoveAssertData(key)
+			}
+			if w == 0 {
+				h.AddExporter(discardExporter{})
+				h.AddWriter(io.Discard, nil)
+			}
+		}(w)
+	}
+	wg.Wait()
+	h.SetDeferAssertions(false)
+	h.ClearDeferred()
+
+	// deferred failures recorded concurrently are all processed exactly once
+	h.SetDeferAssertions(true)
+	var recorded sync.WaitGroup
+	for w := 0; w < Workers; w++ {
+		recorded.Add(1)
+		go func(w int) {
+			defer recorded.Done()
+			h.Assert(ctx, false, "Hammer Deferred", "worker", w)
+		}(w)
+	}
+	recorded.Wait()
+	if n, _ := h.ProcessDeferredAssertions(ctx); n != Workers {
+		t.Fatalf("concurrencytest: expected %d deferred failures, processed %d", Workers, n)
+	}
+	h.SetDeferAssertions(false)
+}
@@ -0,0 +1,19 @@
+package concurrencytest
+
+import (
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+func TestHammer(t *testing.T) {
+	Hammer(t, assert.NewAssertHandler())
+}
+
+func TestHammerIsolatedWithFeatures(t *testing.T) {
+	Hammer(t, assert.NewIsolatedHandler(
+		assert.WithFormatter(&assert.JSONFormatter{Compact: true}),
+		assert.WithDeferredPolicy(assert.DeferredNeverExit),
+		assert.WithHumanDurations(),
+	))
+}
@@ -0,0 +1,28 @@
+package assert
+
+import "context"
+
+type handlerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying a. Package-level assertion functions
+// called with the returned context report through a instead of the default handler.
+func NewContext(ctx context.Context, a Asserter) context.Context {
+	return context.WithValue(ctx, handlerContextKey{}, a)
+}
+
+// FromContext returns the asserter attached to ctx by NewContext, if any
+func FromContext(ctx context.Context) (Asserter, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	a, ok := ctx.Value(handlerContextKey{}).(Asserter)
+	return a, ok && a != nil
+}
+
+// handlerFor resolves the asserter for a package-level call: context first, then the default
+func handlerFor(ctx context.Context) Asserter {
+	if a, ok := FromContext(ctx); ok {
+		return a
+	}
+	return DefaultHandler()
+}
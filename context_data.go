@@ -0,0 +1,31 @@
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+// RegisterContextKey makes failures include ctx.Value(key) under name whenever it is set
+func (a *AssertHandler) RegisterContextKey(name string, key any) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	a.contextKeys[name] = key
+}
+
+// addContextData copies well-known context metadata and registered context values into data
+func (a *AssertHandler) addContextData(ctx context.Context, data map[string]interface{}) {
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline)
+		data["ctx_deadline"] = deadline.Format(time.RFC3339Nano)
+		data["ctx_deadline_remaining"] = remaining.String()
+		data["ctx_deadline_exceeded"] = remaining <= 0
+	}
+	if cause := context.Cause(ctx); cause != nil {
+		data["ctx_cause"] = cause.Error()
+	}
+	for name, key := range a.contextKeys {
+		if v := ctx.Value(key); v != nil {
+			data[name] = v
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type requestIDKey struct{}
+
+func TestContextMetadataExtraction(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	handler.RegisterContextKey("request_id", requestIDKey{})
+
+	ctx := context.WithValue(context.TODO(), requestIDKey{}, "req-7")
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	handler.Assert(ctx, false, "Context Metadata")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("request_id=req-7")) {
+		t.Fatalf("Expected registered context value in output")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("ctx_deadline_remaining=")) {
+		t.Fatalf("Expected remaining deadline in output")
+	}
+}
+
+func TestCanceledContextReportsCause(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	cancel(errors.New("client went away"))
+
+	handler.Assert(ctx, false, "Canceled Context")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("cause: client went away")) {
+		t.Fatalf("Expected cancellation cause in output, got %q", buffer.String())
+	}
+}
+
+func TestExpiredDeadlineIsReported(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	handler.Assert(ctx, false, "Budget Exhausted")
+
+	if exits != 1 {
+		t.Fatalf("Expected failure under an expired deadline to be reported")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("ctx_deadline_exceeded=true")) {
+		t.Fatalf("Expected deadline exceeded flag in output")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("ctx_deadline=")) {
+		t.Fatalf("Expected deadline in output")
+	}
+}
@@ -0,0 +1,28 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestContextScopedHandler(t *testing.T) {
+	var requestBuffer bytes.Buffer
+	requestHandler := NewAssertHandler(WithWriter(&requestBuffer), WithExitFunc(func(code int) {}))
+	requestHandler = requestHandler.WithTags("request_id", "req-42")
+
+	ctx := NewContext(context.TODO(), requestHandler)
+	if h, ok := FromContext(ctx); !ok || h != requestHandler {
+		t.Fatalf("Expected FromContext to return the attached handler")
+	}
+
+	Assert(ctx, false, "Request Scoped Failure")
+
+	if !bytes.Contains(requestBuffer.Bytes(), []byte("request_id=req-42")) {
+		t.Fatalf("Expected failure to be reported through the context handler")
+	}
+
+	if _, ok := FromContext(context.TODO()); ok {
+		t.Fatalf("Expected no handler on a bare context")
+	}
+}
@@ -0,0 +1,126 @@
+package assert
+
+import (
+	"context"
+	"runtime"
+)
+
+// Kind classifies an assertion so it can be handled differently from others
+type Kind string
+
+const (
+	KindAssert        Kind = ""
+	KindPrecondition  Kind = "precondition"
+	KindPostcondition Kind = "postcondition"
+	KindInvariant     Kind = "invariant"
+)
+
+// Action is what a handler does once a fatal failure of a given kind has been reported
+type Action int
+
+const (
+	// ActionExit defers the failure in deferred mode, otherwise runs the exit function
+	ActionExit Action = iota
+	// ActionPanic panics with the *AssertionError
+	ActionPanic
+	// ActionLog only reports the failure
+	ActionLog
+)
+
+// WithKindAction sets the action taken for fatal failures of kind, e.g. making
+// preconditions panic while invariants only log
+func WithKindAction(kind Kind, action Action) Option {
+	return func(a *AssertHandler) {
+		a.kindActions[kind] = action
+	}
+}
+
+// Require checks a precondition of the calling function
+func (a *AssertHandler) Require(ctx context.Context, cond bool, msg string, data ...any) {
+	if !cond {
+		a.contract(ctx, KindPrecondition, 2, msg, data)
+		return
+	}
+	a.passed(KindPrecondition)
+}
+
+// Ensure checks a postcondition of the calling function
+func (a *AssertHandler) Ensure(ctx context.Context, cond bool, msg string, data ...any) {
+	if !cond {
+		a.contract(ctx, KindPostcondition, 2, msg, data)
+		return
+	}
+	a.passed(KindPostcondition)
+}
+
+// Invariant checks an invariant that must hold in the calling function
+func (a *AssertHandler) Invariant(ctx context.Context, cond bool, msg string, data ...any) {
+	if !cond {
+		a.contract(ctx, KindInvariant, 2, msg, data)
+		return
+	}
+	a.passed(KindInvariant)
+}
+
+// contract reports a contract failure tagged with its kind and the function skip frames up
+func (a *AssertHandler) contract(ctx context.Context, kind Kind, skip int, msg string, data []any) {
+	data = append(data, "contract", string(kind), "function", callerFunction(skip+1))
+	a.report(ctx, failure{severity: SeverityError, kind: kind, msg: msg, args: data})
+}
+
+func callerFunction(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		return fn.Name()
+	}
+	return "unknown"
+}
+
+// Require checks a precondition of the calling function through the default handler
+func Require(ctx context.Context, cond bool, msg string, data ...any) {
+	if !cond {
+		contractFor(ctx, KindPrecondition, msg, data)
+		return
+	}
+	passedFor(ctx, KindPrecondition)
+}
+
+// Ensure checks a postcondition of the calling function through the default handler
+func Ensure(ctx context.Context, cond bool, msg string, data ...any) {
+	if !cond {
+		contractFor(ctx, KindPostcondition, msg, data)
+		return
+	}
+	passedFor(ctx, KindPostcondition)
+}
+
+// Invariant checks an invariant of the calling function through the default handler
+func Invariant(ctx context.Context, cond bool, msg string, data ...any) {
+	if !cond {
+		contractFor(ctx, KindInvariant, msg, data)
+		return
+	}
+	passedFor(ctx, KindInvariant)
+}
+
+// contractFor reports a package-level contract failure, keeping the caller's function
+// name when the resolved asserter is a concrete handler
+func contractFor(ctx context.Context, kind Kind, msg string, data []any) {
+	a := handlerFor(ctx)
+	if h, ok := a.(*AssertHandler); ok {
+		h.contract(ctx, kind, 3, msg, data)
+		return
+	}
+
+	switch kind {
+	case KindPrecondition:
+		a.Require(ctx, false, msg, data...)
+	case KindPostcondition:
+		a.Ensure(ctx, false, msg, data...)
+	default:
+		a.Invariant(ctx, false, msg, data...)
+	}
+}
@@ -0,0 +1,65 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func withdraw(handler *AssertHandler, amount int) {
+	handler.Require(context.TODO(), amount > 0, "Amount Must Be Positive", "amount", amount)
+}
+
+func TestContractRecordsKindAndFunction(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	withdraw(handler, -1)
+
+	if !bytes.Contains(buffer.Bytes(), []byte("contract=precondition")) {
+		t.Fatalf("Expected contract kind in output")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("function=github.com/ZanzyTHEbar/assert-lib.withdraw")) {
+		t.Fatalf("Expected enclosing function in output, got %s", buffer.String())
+	}
+}
+
+func TestContractKindActions(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) { exits++ }),
+		WithKindAction(KindPrecondition, ActionPanic),
+		WithKindAction(KindInvariant, ActionLog),
+	)
+
+	handler.Invariant(context.TODO(), false, "Cache Size Drift")
+	if exits != 0 {
+		t.Fatalf("Expected invariant failure to only log")
+	}
+
+	handler.Ensure(context.TODO(), false, "Result Sorted")
+	if exits != 1 {
+		t.Fatalf("Expected postcondition failure to use the default exit action")
+	}
+
+	defer func() {
+		err, ok := recover().(*AssertionError)
+		if !ok || err.Msg != "Input Valid" {
+			t.Fatalf("Expected precondition failure to panic with an AssertionError, got %v", err)
+		}
+	}()
+	handler.Require(context.TODO(), false, "Input Valid")
+}
+
+func TestPackageLevelContractRecordsFunction(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	Invariant(NewContext(context.TODO(), handler), false, "Package Invariant")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("function=github.com/ZanzyTHEbar/assert-lib.TestPackageLevelContractRecordsFunction")) {
+		t.Fatalf("Expected calling test function in output, got %s", buffer.String())
+	}
+}
@@ -0,0 +1,115 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// exportFlusher is implemented by exporters that buffer events asynchronously, such as Batcher
+type exportFlusher interface {
+	Flush(ctx context.Context) error
+}
+
+// WithCrashOnFailure writes the formatted event of every fatal failure to crashFile before
+// the exit function runs. An empty path disables the crash file.
+func WithCrashOnFailure(crashFile string) Option {
+	return func(a *AssertHandler) {
+		a.crashFile = crashFile
+	}
+}
+
+// defaultFlushTimeout bounds the flushes before exit unless WithFlushTimeout changes it
+const defaultFlushTimeout = 5 * time.Second
+
+// WithFlushTimeout bounds how long the flushes before exit and after every event in
+// FlushEveryEvent mode wait for exporters, writers and the state store, so a stuck backend
+// cannot hold the process up. Flushes still running when d expires are abandoned and counted
+// in AbandonedFlushes. Zero or less waits without a deadline. The default is 5 seconds.
+func WithFlushTimeout(d time.Duration) Option {
+	return func(a *AssertHandler) {
+		a.flushTimeout = d
+	}
+}
+
+// AbandonedFlushes returns how many flushes were abandoned because the flush timeout expired,
+// leaving what they buffered unwritten
+func (a *AssertHandler) AbandonedFlushes() uint64 {
+	return a.abandoned.Load()
+}
+
+// flushContext returns ctx without its cancellation, so a canceled request still flushes,
+// bounded by the flush timeout instead
+func (a *AssertHandler) flushContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = context.WithoutCancel(ctx)
+	if a.flushTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, a.flushTimeout)
+}
+
+// flushFailed counts err if the flush timeout abandoned the flush that returned it
+func (a *AssertHandler) flushFailed(err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		a.abandoned.Add(1)
+	}
+}
+
+// prepareExit makes sure the event that is about to terminate the process is not lost:
+// it is appended to the crash file, profiles are dumped, the policy state is saved and
+// buffering exporters and writers are flushed synchronously, all within the flush timeout.
+// Callers must hold flushLock.
+func (a *AssertHandler) prepareExit(ctx context.Context, formatted string) {
+	if a.crashFile != "" {
+		if err := appendCrashFile(a.crashFile, formatted); err != nil {
+			fmt.Fprintln(a.writer, "Crash file error:", err)
+		}
+	}
+
+	a.dumpProfiles()
+
+	ctx, cancel := a.flushContext(ctx)
+	defer cancel()
+
+	// enriched reports go to the exporters and writers flushed below
+	if a.twoStage != nil {
+		if err := a.twoStage.flush(ctx); err != nil {
+			a.flushFailed(err)
+			fmt.Fprintln(a.writer, "Enrichment flush error:", err)
+		}
+	}
+
+	if err := a.SaveState(ctx); err != nil {
+		a.flushFailed(err)
+		fmt.Fprintln(a.writer, "State store error:", err)
+	}
+
+	for _, e := range a.exporters {
+		if f, ok := e.(exportFlusher); ok {
+			if err := f.Flush(ctx); err != nil {
+				a.flushFailed(err)
+				fmt.Fprintln(a.writer, "Exporter flush error:", err)
+			}
+		}
+	}
+
+	a.flushWriters(ctx)
+}
+
+func appendCrashFile(path, formatted string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, formatted); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,83 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrashOnFailureFlushesBeforeExit(t *testing.T) {
+	var buffer bytes.Buffer
+	next := &countingExporter{}
+	batcher := NewBatcher(next, BatcherConfig{MaxDelay: time.Hour})
+	defer batcher.Shutdown(context.TODO())
+	crashFile := filepath.Join(t.TempDir(), "crash.log")
+
+	exported := -1
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExporter(batcher),
+		WithCrashOnFailure(crashFile),
+		WithExitFunc(func(code int) { exported = int(batcher.Stats().Exported) }),
+	)
+
+	handler.Assert(context.TODO(), false, "Fatal Crash")
+
+	if exported != 1 {
+		t.Fatalf("Expected the event to be exported before exit, got %d", exported)
+	}
+	contents, err := os.ReadFile(crashFile)
+	if err != nil {
+		t.Fatalf("Expected crash file to be written: %v", err)
+	}
+	if !bytes.Contains(contents, []byte("Fatal Crash")) {
+		t.Fatalf("Expected failure in crash file")
+	}
+}
+
+func TestCrashFileIsClosed(t *testing.T) {
+	before, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip("open descriptors cannot be listed on this platform")
+	}
+	handler := NewAssertHandler(WithWriter(&bytes.Buffer{}), WithExitFunc(func(code int) {}),
+		WithCrashOnFailure(filepath.Join(t.TempDir(), "crash.log")))
+	for i := 0; i < 20; i++ {
+		handler.Assert(context.TODO(), false, "Repeated Crash")
+	}
+
+	after, _ := os.ReadDir("/proc/self/fd")
+	if len(after) > len(before)+2 {
+		t.Fatalf("Expected the crash file to be closed after each write, %d descriptors open before and %d after", len(before), len(after))
+	}
+}
+
+// stuckExporter is an exporter whose Flush waits until ctx is done
+type stuckExporter struct{}
+
+func (stuckExporter) Export(ctx context.Context, events []AssertionEvent) error { return nil }
+func (stuckExporter) Shutdown(ctx context.Context) error                        { return nil }
+func (stuckExporter) Flush(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestExitFlushTimeout(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithFlushTimeout(20*time.Millisecond))
+	handler.AddExporter(stuckExporter{})
+
+	start := time.Now()
+	handler.Assert(context.TODO(), false, "Stuck Backend")
+	if elapsed := time.Since(start); elapsed > time.Second || exits != 1 {
+		t.Fatalf("Expected the stuck flush to be abandoned before exiting, took %v with %d exits", elapsed, exits)
+	}
+	if handler.AbandonedFlushes() != 1 || !strings.Contains(buffer.String(), "Exporter flush error: context deadline exceeded") {
+		t.Fatalf("Expected the abandoned flush to be counted and reported, got %d in %q", handler.AbandonedFlushes(), buffer.String())
+	}
+}
@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"strconv"
+)
+
+// WithDebuggerBreak stops in the debugger when a failure takes the exit path while a
+// debugger such as Delve is attached, after the failure was written and exported, so the
+// live state can be inspected where the invariant broke. Without a debugger it does nothing.
+// Debuggers are detected through /proc on Linux and not detected on other systems.
+func WithDebuggerBreak() Option {
+	return func(a *AssertHandler) {
+		a.debuggerBreak = true
+	}
+}
+
+// debuggerAttached and breakpoint are variables so tests can replace them
+var (
+	debuggerAttached = tracerAttached
+	breakpoint       = runtime.Breakpoint
+)
+
+// breakIntoDebugger issues a breakpoint if WithDebuggerBreak is set and a debugger is attached.
+// Callers must hold flushLock.
+func (a *AssertHandler) breakIntoDebugger() {
+	if a.debuggerBreak && debuggerAttached() {
+		breakpoint()
+	}
+}
+
+// tracerAttached reports whether another process traces this one
+func tracerAttached() bool {
+	status, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return false
+	}
+	for _, line := range bytes.Split(status, []byte("\n")) {
+		if value, ok := bytes.CutPrefix(line, []byte("TracerPid:")); ok {
+			pid, err := strconv.Atoi(string(bytes.TrimSpace(value)))
+			return err == nil && pid != 0
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDebuggerBreak(t *testing.T) {
+	attached := false
+	breaks := 0
+	defer func(attachedFunc func() bool, breakFunc func()) {
+		debuggerAttached, breakpoint = attachedFunc, breakFunc
+	}(debuggerAttached, breakpoint)
+	debuggerAttached = func() bool { return attached }
+
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithDebuggerBreak())
+	breakpoint = func() {
+		breaks++
+		if !strings.Contains(buffer.String(), "Invariant Broken") {
+			t.Fatalf("Expected the failure to be written before the breakpoint")
+		}
+	}
+	ctx := context.TODO()
+
+	handler.Assert(ctx, false, "Invariant Broken")
+	if breaks != 0 {
+		t.Fatalf("Expected no breakpoint without a debugger")
+	}
+
+	attached = true
+	handler.With(WithErrorSeverityMapper(func(error) Severity { return SeverityWarn })).NoError(ctx, os.ErrClosed, "Invariant Broken")
+	if breaks != 0 {
+		t.Fatalf("Expected no breakpoint for failures that do not exit")
+	}
+
+	handler.Assert(ctx, false, "Invariant Broken")
+	if breaks != 1 {
+		t.Fatalf("Expected a breakpoint with a debugger attached, got %d", breaks)
+	}
+
+	NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {})).Assert(ctx, false, "Invariant Broken")
+	if breaks != 1 {
+		t.Fatalf("Expected no breakpoint without WithDebuggerBreak, got %d", breaks)
+	}
+}
@@ -0,0 +1,177 @@
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+// Decorator wraps an Asserter with additional behavior
+type Decorator func(Asserter) Asserter
+
+// WrapHandler applies decorators to a; the first decorator is the outermost one
+func WrapHandler(a Asserter, decorators ...Decorator) Asserter {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		a = decorators[i](a)
+	}
+	return a
+}
+
+// Call describes one assertion call seen by an Interceptor. Msg may be rewritten;
+// Failed reports whether the check failed and is final once the done func runs.
+type Call struct {
+	Method string
+	Msg    string
+	Failed bool
+}
+
+// Interceptor runs around every call of a decorated Asserter. The returned done func,
+// if not nil, runs after the wrapped call completes.
+type Interceptor func(ctx context.Context, call *Call) (context.Context, func())
+
+// Intercept returns a Decorator running fn around every assertion call
+func Intercept(fn Interceptor) Decorator {
+	return func(next Asserter) Asserter {
+		return &intercepted{next: next, fn: fn}
+	}
+}
+
+// WithPrefix prepends prefix to every assertion message
+func WithPrefix(prefix string) Decorator {
+	return Intercept(func(ctx context.Context, call *Call) (context.Context, func()) {
+		call.Msg = prefix + call.Msg
+		return ctx, nil
+	})
+}
+
+// WithMetricsDecorator calls record once per assertion with the method name and outcome
+func WithMetricsDecorator(record func(method string, failed bool)) Decorator {
+	return Intercept(func(ctx context.Context, call *Call) (context.Context, func()) {
+		return ctx, func() { record(call.Method, call.Failed) }
+	})
+}
+
+// WithTracingDecorator wraps every assertion in a span started by start, which returns the
+// span context and a func ending the span; it stays independent of any tracing library
+func WithTracingDecorator(start func(ctx context.Context, name string) (context.Context, func())) Decorator {
+	return Intercept(func(ctx context.Context, call *Call) (context.Context, func()) {
+		return start(ctx, "assert."+call.Method)
+	})
+}
+
+type intercepted struct {
+	next Asserter
+	fn   Interceptor
+}
+
+func (i *intercepted) around(ctx context.Context, method, msg string, failed bool, call func(ctx context.Context, msg string)) {
+	c := &Call{Method: method, Msg: msg, Failed: failed}
+	ctx, done := i.fn(ctx, c)
+	if done != nil {
+		defer done()
+	}
+	call(ctx, c.Msg)
+}
+
+func (i *intercepted) Assert(ctx context.Context, truth bool, msg string, data ...any) {
+	i.around(ctx, "Assert", msg, !truth, func(ctx context.Context, msg string) {
+		i.next.Assert(ctx, truth, msg, data...)
+	})
+}
+
+func (i *intercepted) AssertWithTimeout(ctx context.Context, timeout time.Duration, truth bool, msg string, data ...any) {
+	i.around(ctx, "AssertWithTimeout", msg, !truth, func(ctx context.Context, msg string) {
+		i.next.AssertWithTimeout(ctx, timeout, truth, msg, data...)
+	})
+}
+
+func (i *intercepted) Nil(ctx context.Context, item any, msg string, data ...any) {
+	i.around(ctx, "Nil", msg, !isNil(item), func(ctx context.Context, msg string) {
+		i.next.Nil(ctx, item, msg, data...)
+	})
+}
+
+func (i *intercepted) NotNil(ctx context.Context, item any, msg string, data ...any) {
+	i.around(ctx, "NotNil", msg, isNil(item), func(ctx context.Context, msg string) {
+		i.next.NotNil(ctx, item, msg, data...)
+	})
+}
+
+func (i *intercepted) Never(ctx context.Context, msg string, data ...any) {
+	i.around(ctx, "Never", msg, true, func(ctx context.Context, msg string) {
+		i.next.Never(ctx, msg, data...)
+	})
+}
+
+func (i *intercepted) NoError(ctx context.Context, err error, msg string, data ...any) {
+	i.around(ctx, "NoError", msg, err != nil, func(ctx context.Context, msg string) {
+		i.next.NoError(ctx, err, msg, data...)
+	})
+}
+
+func (i *intercepted) NoErrorEventually(ctx context.Context, fn func() error, timeout, interval time.Duration, msg string, data ...any) {
+	c := &Call{Method: "NoErrorEventually", Msg: msg}
+	ctx, done := i.fn(ctx, c)
+	if done != nil {
+		defer done()
+	}
+	i.next.NoErrorEventually(ctx, func() error {
+		err := fn()
+		c.Failed = err != nil
+		return err
+	}, timeout, interval, c.Msg, data...)
+}
+
+func (i *intercepted) Require(ctx context.Context, cond bool, msg string, data ...any) {
+	i.around(ctx, "Require", msg, !cond, func(ctx context.Context, msg string) {
+		i.next.Require(ctx, cond, msg, data...)
+	})
+}
+
+func (i *intercepted) Ensure(ctx context.Context, cond bool, msg string, data ...any) {
+	i.around(ctx, "Ensure", msg, !cond, func(ctx context.Context, msg string) {
+		i.next.Ensure(ctx, cond, msg, data...)
+	})
+}
+
+func (i *intercepted) Invariant(ctx context.Context, cond bool, msg string, data ...any) {
+	i.around(ctx, "Invariant", msg, !cond, func(ctx context.Context, msg string) {
+		i.next.Invariant(ctx, cond, msg, data...)
+	})
+}
+
+func (i *intercepted) Unreachable(ctx context.Context, msg string, data ...any) error {
+	i.around(ctx, "Unreachable", msg, true, func(ctx context.Context, msg string) {
+		i.next.Unreachable(ctx, msg, data...)
+	})
+	return nil
+}
+
+func (i *intercepted) ProcessDeferredAssertions(ctx context.Context) (int, error) {
+	return i.next.ProcessDeferredAssertions(ctx)
+}
+
+// FlagEnabled looks up flags through the wrapped Asserter, if it supports them
+func (i *intercepted) FlagEnabled(name string) bool {
+	f, ok := i.next.(flagger)
+	return ok && f.FlagEnabled(name)
+}
+
+// Pass records a passing check through the wrapped Asserter, if it supports them
+func (i *intercepted) Pass(ctx context.Context, code, msg string, data ...any) {
+	i.around(ctx, "Pass", msg, false, func(ctx context.Context, msg string) {
+		if p, ok := i.next.(passer); ok {
+			p.Pass(ctx, code, msg, data...)
+		}
+	})
+}
+
+// ReportPanic reports a recovered panic through the wrapped Asserter
+func (i *intercepted) ReportPanic(ctx context.Context, value any, stack []byte) {
+	i.around(ctx, "ReportPanic", "Recovered Panic", true, func(ctx context.Context, msg string) {
+		if p, ok := i.next.(panicReporter); ok {
+			p.ReportPanic(ctx, value, stack)
+			return
+		}
+		i.next.Never(ctx, msg, panicData(value, stack)...)
+	})
+}
@@ -0,0 +1,40 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestWrapHandlerDecorators(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	counts := map[string]int{}
+	spans := []string{}
+	wrapped := WrapHandler(handler,
+		WithTracingDecorator(func(ctx context.Context, name string) (context.Context, func()) {
+			spans = append(spans, name)
+			return ctx, func() {}
+		}),
+		WithMetricsDecorator(func(method string, failed bool) {
+			if failed {
+				counts[method]++
+			}
+		}),
+		WithPrefix("[billing] "),
+	)
+
+	wrapped.Assert(context.TODO(), true, "Passing Check")
+	wrapped.Assert(context.TODO(), false, "Failing Check")
+
+	if counts["Assert"] != 1 {
+		t.Fatalf("Expected one failed Assert recorded, got %v", counts)
+	}
+	if len(spans) != 2 || spans[0] != "assert.Assert" {
+		t.Fatalf("Expected a span per call, got %v", spans)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("msg=[billing] Failing Check")) {
+		t.Fatalf("Expected prefixed message in output, got %s", buffer.String())
+	}
+}
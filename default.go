@@ -0,0 +1,85 @@
+package assert
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	defaultMu      sync.RWMutex
+	defaultHandler *AssertHandler
+)
+
+// DefaultHandler returns the handler used by the package-level assertion functions,
+// creating it on first use
+func DefaultHandler() *AssertHandler {
+	defaultMu.RLock()
+	h := defaultHandler
+	defaultMu.RUnlock()
+	if h != nil {
+		return h
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultHandler == nil {
+		defaultHandler = NewAssertHandler()
+	}
+	return defaultHandler
+}
+
+// SetDefaultHandler replaces the handler used by the package-level assertion functions.
+// Passing nil restores a fresh default handler on next use. It panics if h is isolated.
+// The subsystem handlers of Named are derived from the new handler on next use.
+func SetDefaultHandler(h *AssertHandler) {
+	if h != nil && h.isolated {
+		panic("assert: an isolated handler cannot be installed as the default handler")
+	}
+
+	defaultMu.Lock()
+	defaultHandler = h
+	defaultMu.Unlock()
+
+	// Named takes namedMu before defaultMu, so the cache is reset after unlocking
+	ResetNamed()
+}
+
+// The package-level functions below report through the handler attached to ctx with
+// NewContext, falling back to the default handler. Like the handler methods, their data
+// may mix key/value pairs, Fields and per-call Options.
+
+// Assert fails through the default handler when truth is false
+func Assert(ctx context.Context, truth bool, msg string, data ...any) {
+	handlerFor(ctx).Assert(ctx, truth, msg, data...)
+}
+
+// AssertWithTimeout is Assert bounded by timeout, through the default handler
+func AssertWithTimeout(ctx context.Context, timeout time.Duration, truth bool, msg string, data ...any) {
+	handlerFor(ctx).AssertWithTimeout(ctx, timeout, truth, msg, data...)
+}
+
+// Nil fails through the default handler when item is not nil
+func Nil(ctx context.Context, item any, msg string, data ...any) {
+	handlerFor(ctx).Nil(ctx, item, msg, data...)
+}
+
+// NotNil fails through the default handler when item is nil
+func NotNil(ctx context.Context, item any, msg string, data ...any) {
+	handlerFor(ctx).NotNil(ctx, item, msg, data...)
+}
+
+// Never always fails through the default handler
+func Never(ctx context.Context, msg string, data ...any) {
+	handlerFor(ctx).Never(ctx, msg, data...)
+}
+
+// NoError fails through the default handler when err is not nil
+func NoError(ctx context.Context, err error, msg string, data ...any) {
+	handlerFor(ctx).NoError(ctx, err, msg, data...)
+}
+
+// ProcessDeferredAssertions processes the deferred assertions of the default handler
+func ProcessDeferredAssertions(ctx context.Context) (int, error) {
+	return handlerFor(ctx).ProcessDeferredAssertions(ctx)
+}
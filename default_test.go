@@ -0,0 +1,98 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestSetDefaultHandler(t *testing.T) {
+	defer SetDefaultHandler(nil)
+
+	var buffer bytes.Buffer
+	handler := NewAssertHandler()
+	handler.ToWriter(&buffer)
+	handler.SetExitFunc(func(code int) {})
+	SetDefaultHandler(handler)
+
+	if DefaultHandler() != handler {
+		t.Fatalf("Expected DefaultHandler to return the configured handler")
+	}
+
+	Assert(context.TODO(), false, "Package Level Failure")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("Package Level Failure")) {
+		t.Fatalf("Expected failure message not found in output")
+	}
+
+	SetDefaultHandler(nil)
+	if DefaultHandler() == handler || DefaultHandler() == nil {
+		t.Fatalf("Expected a fresh default handler after reset")
+	}
+}
+
+func TestConfigurePersists(t *testing.T) {
+	defer SetDefaultHandler(nil)
+	SetDefaultHandler(nil)
+
+	var buffer bytes.Buffer
+	exits := 0
+	Configure(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithFormatter(&JSONFormatter{}))
+
+	Assert(context.TODO(), false, "First Failure")
+	Assert(context.TODO(), false, "Second Failure")
+
+	if exits != 2 {
+		t.Fatalf("Expected configured exit func to be used for every call, got %d calls", exits)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte(`"msg": "Second Failure"`)) {
+		t.Fatalf("Expected configured formatter output")
+	}
+}
+
+func TestPackageLevelDeferredAssertions(t *testing.T) {
+	defer SetDefaultHandler(nil)
+	SetDefaultHandler(nil)
+
+	var buffer bytes.Buffer
+	exits := 0
+	Configure(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithDeferMode(true))
+
+	Assert(context.TODO(), false, "Deferred Package Failure")
+	Named("deferred-subsystem").Assert(context.TODO(), false, "Deferred Subsystem Failure")
+
+	if exits != 0 {
+		t.Fatalf("Expected no exit while deferring")
+	}
+
+	buffer.Reset()
+	ProcessDeferredAssertions(context.TODO())
+
+	if exits != 1 {
+		t.Fatalf("Expected a single exit after processing, got %d", exits)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("Deferred Package Failure")) ||
+		!bytes.Contains(buffer.Bytes(), []byte("Deferred Subsystem Failure")) {
+		t.Fatalf("Expected both deferred failures in processed output")
+	}
+
+	ProcessDeferredAssertions(context.TODO())
+	if exits != 1 {
+		t.Fatalf("Expected deferred failures to be cleared after processing")
+	}
+}
+
+func TestConfigureAfterFailuresWarns(t *testing.T) {
+	defer SetDefaultHandler(nil)
+	SetDefaultHandler(nil)
+
+	var early, late bytes.Buffer
+	Configure(WithWriter(&early), WithExitFunc(func(code int) {}))
+	Assert(context.TODO(), false, "Early Failure")
+
+	Configure(WithWriter(&late))
+
+	if !bytes.Contains(late.Bytes(), []byte("Configure called after 1 failures")) {
+		t.Fatalf("Expected late configuration warning, got %q", late.String())
+	}
+}
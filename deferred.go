@@ -0,0 +1,214 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// deferredFailure is a failure held back in deferred mode
+type deferredFailure struct {
+	event     AssertionEvent
+	formatted string
+}
+
+// deferredStore holds deferred failures; derived handlers share their parent's store
+// so a single ProcessDeferredAssertions call sees everything deferred through them
+type deferredStore struct {
+	mu       sync.Mutex
+	failures []deferredFailure
+}
+
+// add stores a failure and returns how many are now pending
+func (d *deferredStore) add(event AssertionEvent, formatted string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failures = append(d.failures, deferredFailure{event: event, formatted: formatted})
+	return len(d.failures)
+}
+
+func (d *deferredStore) drain() []deferredFailure {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	failures := d.failures
+	d.failures = nil
+	return failures
+}
+
+func (d *deferredStore) snapshot() []deferredFailure {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]deferredFailure(nil), d.failures...)
+}
+
+// DeferredPolicy decides whether processing deferred failures runs the exit path
+type DeferredPolicy int
+
+const (
+	// DeferredExitAlways exits whenever there was at least one deferred failure
+	DeferredExitAlways DeferredPolicy = iota
+	// DeferredExitOnFatal exits only if one of the deferred failures has SeverityFatal
+	DeferredExitOnFatal
+	// DeferredNeverExit only reports and returns the aggregate, for use as a validation collector
+	DeferredNeverExit
+)
+
+// WithDeferredPolicy sets when processing deferred failures exits
+func WithDeferredPolicy(policy DeferredPolicy) Option {
+	return func(a *AssertHandler) {
+		a.deferredPolicy = policy
+	}
+}
+
+// WithMaxDeferred fails fast once n failures are pending in deferred mode: they are
+// processed immediately and the exit path runs regardless of the deferred policy
+func WithMaxDeferred(n int) Option {
+	return func(a *AssertHandler) {
+		a.maxDeferred = n
+	}
+}
+
+// WithDeferUntil bounds deferred mode and deferred scopes: once n failures are pending they
+// are processed immediately under the deferred policy, as if ProcessDeferredAssertions or the
+// scope's done function had been called. Unless the policy exits, collection then continues.
+func WithDeferUntil(n int) Option {
+	return func(a *AssertHandler) {
+		a.deferUntil = n
+	}
+}
+
+// processDeferredUntil processes the pending failures of store after WithDeferUntil was reached
+func (a *AssertHandler) processDeferredUntil(ctx context.Context, store *deferredStore) {
+	a.processDeferred(ctx, store.drain())
+}
+
+// failFastDeferred processes the pending failures after WithMaxDeferred was reached
+func (a *AssertHandler) failFastDeferred(ctx context.Context) {
+	a.processDeferredWithPolicy(ctx, a.deferred.drain(), DeferredExitAlways)
+}
+
+// Process all deferred assertions at once, logging or exiting if needed.
+// It returns how many failures were processed and, if any, an *AssertionError summarizing them.
+func (a *AssertHandler) ProcessDeferredAssertions(ctx context.Context) (int, error) {
+	// Take and clear the deferred errors in one step so concurrent assertions are not lost
+	failures := a.deferred.drain()
+	return len(failures), a.processDeferred(ctx, failures)
+}
+
+// DeferredCount returns how many failures are waiting to be processed
+func (a *AssertHandler) DeferredCount() int {
+	a.deferred.mu.Lock()
+	defer a.deferred.mu.Unlock()
+	return len(a.deferred.failures)
+}
+
+// DeferredEvents returns the failures waiting to be processed, oldest first
+func (a *AssertHandler) DeferredEvents() []AssertionEvent {
+	failures := a.deferred.snapshot()
+	events := make([]AssertionEvent, len(failures))
+	for i, f := range failures {
+		events[i] = f.event
+	}
+	return events
+}
+
+// ClearDeferred discards the deferred failures without reporting them, returning how many there were
+func (a *AssertHandler) ClearDeferred() int {
+	return len(a.deferred.drain())
+}
+
+// processDeferred reports a drained set of deferred failures and runs the exit path
+// according to the handler's deferred policy
+func (a *AssertHandler) processDeferred(ctx context.Context, failures []deferredFailure) error {
+	a.flushLock.Lock()
+	policy := a.deferredPolicy
+	a.flushLock.Unlock()
+
+	return a.processDeferredWithPolicy(ctx, failures, policy)
+}
+
+func (a *AssertHandler) processDeferredWithPolicy(ctx context.Context, failures []deferredFailure, policy DeferredPolicy) error {
+	if len(failures) == 0 {
+		// groups that all passed are summarized too
+		a.flushLock.Lock()
+		a.writeGroupSummaries()
+		a.flushLock.Unlock()
+		return nil
+	}
+
+	exit := policy == DeferredExitAlways
+	if policy == DeferredExitOnFatal {
+		for _, f := range failures {
+			exit = exit || f.event.Severity == SeverityFatal
+		}
+	}
+
+	// Combine all errors into a single string
+	formatted := make([]string, len(failures))
+	for i, f := range failures {
+		formatted[i] = f.formatted
+	}
+	combinedErrors := strings.Join(formatted, "\n---\n")
+
+	a.flushLock.Lock()
+	a.writeGroupSummaries()
+	a.writeTeeDeferred(failures)
+	if exit {
+		a.breakIntoDebugger()
+	}
+	if exit && a.userMessage != nil {
+		a.writeUserMessage(fmt.Sprintf("%d deferred assertions failed", len(failures)), map[string]interface{}{})
+		a.prepareExit(ctx, combinedErrors)
+	} else {
+		a.writeRecords(a.writer, formatted)
+		if exit {
+			a.prepareExit(ctx, combinedErrors)
+		} else {
+			a.flushAfterEvent(ctx)
+		}
+	}
+	summary := deferredSummary(failures, combinedErrors)
+	failurePolicy := a.failurePolicyFor(summary)
+	usePanic := a.exitPanic
+	strict := a.strictControlFlow
+	a.flushLock.Unlock()
+
+	deferredErr := newAssertionError(summary)
+	if !exit {
+		return deferredErr
+	}
+	if usePanic {
+		panic(exitPanic{err: deferredErr})
+	}
+
+	failurePolicy.Handle(summary)
+
+	if strict {
+		panic(deferredErr)
+	}
+	return deferredErr
+}
+
+// deferredSummary is the event standing for all of failures once they are processed. It has
+// their highest severity, and their kind if they all share one.
+func deferredSummary(failures []deferredFailure, combinedErrors string) AssertionEvent {
+	summary := AssertionEvent{
+		Time:     time.Now(),
+		Severity: SeverityError,
+		Kind:     failures[0].event.Kind,
+		Message:  "deferred assertions failed",
+		Data:     map[string]interface{}{"count": len(failures), "errors": combinedErrors},
+	}
+	errs := make([]*AssertionError, len(failures))
+	for i, f := range failures {
+		errs[i] = newAssertionError(f.event)
+		summary.Severity = max(summary.Severity, f.event.Severity)
+		if f.event.Kind != summary.Kind {
+			summary.Kind = KindAssert
+		}
+	}
+	summary.Data[failuresKey] = errs
+	return summary
+}
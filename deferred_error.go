@@ -0,0 +1,59 @@
+package assert
+
+import (
+	"context"
+	"strings"
+)
+
+// DeferredFailures is the error returned by DeferredError. Each deferred failure is an
+// *AssertionError reachable through errors.Is and errors.As.
+type DeferredFailures struct {
+	Failures []*AssertionError
+}
+
+func (e *DeferredFailures) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the individual failures, making the error compatible with errors.Join
+func (e *DeferredFailures) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// DeferredError takes the pending deferred failures without reporting them and returns them
+// joined into a single *DeferredFailures, or nil if there were none. Use it to return the
+// result of a validation pass up the stack instead of processing it in place.
+func (a *AssertHandler) DeferredError() error {
+	return failuresError(a.deferred.drain())
+}
+
+// ScopeError takes the failures collected in the deferred scope of ctx, begun with
+// BeginDeferredScope, and returns them like DeferredError. The scope's done function then
+// has nothing left to process. ScopeError returns nil when ctx carries no scope of a.
+func (a *AssertHandler) ScopeError(ctx context.Context) error {
+	scope := deferredScopeFrom(ctx, a.deferred)
+	if scope == nil {
+		return nil
+	}
+	return failuresError(scope.drain())
+}
+
+func failuresError(failures []deferredFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	errs := make([]*AssertionError, len(failures))
+	for i, f := range failures {
+		errs[i] = newAssertionError(f.event)
+	}
+	return &DeferredFailures{Failures: errs}
+}
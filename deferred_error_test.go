@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDeferredError(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithDeferMode(true))
+
+	if err := handler.DeferredError(); err != nil {
+		t.Fatalf("Expected no error without deferred failures, got %v", err)
+	}
+
+	handler.Assert(context.TODO(), false, "Row 1 Invalid")
+	handler.NoError(context.TODO(), io.ErrUnexpectedEOF, "Row 2 Truncated")
+
+	err := handler.DeferredError()
+	var joined *DeferredFailures
+	if !errors.As(err, &joined) || len(joined.Failures) != 2 {
+		t.Fatalf("Expected two joined failures, got %v", err)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected errors.Is to reach the wrapped error")
+	}
+	var assertionErr *AssertionError
+	if !errors.As(err, &assertionErr) || assertionErr.Msg != "Row 1 Invalid" {
+		t.Fatalf("Expected errors.As to find the first failure, got %v", assertionErr)
+	}
+	if err.Error() != "assertion failed: Row 1 Invalid\nassertion failed: Row 2 Truncated" {
+		t.Fatalf("Unexpected error text: %q", err.Error())
+	}
+	if handler.DeferredCount() != 0 {
+		t.Fatalf("Expected DeferredError to take the pending failures")
+	}
+}
+
+func TestScopeError(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+
+	if handler.ScopeError(context.TODO()) != nil {
+		t.Fatalf("Expected no error without a scope")
+	}
+	ctx, done := handler.BeginDeferredScope(context.TODO())
+	handler.Assert(ctx, false, "Scoped Failure")
+	err := handler.ScopeError(ctx)
+
+	var failures *DeferredFailures
+	if !errors.As(err, &failures) || len(failures.Failures) != 1 || failures.Failures[0].Msg != "Scoped Failure" {
+		t.Fatalf("Expected the scoped failure, got %v", err)
+	}
+	done()
+	if exits != 0 || handler.ScopeError(ctx) != nil {
+		t.Fatalf("Expected the scope to be drained")
+	}
+}
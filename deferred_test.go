@@ -0,0 +1,130 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDeferredAccessorsAndResults(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithDeferMode(true))
+
+	handler.Assert(context.TODO(), false, "Row 1 Invalid")
+	handler.Assert(context.TODO(), false, "Row 2 Invalid")
+
+	if handler.DeferredCount() != 2 {
+		t.Fatalf("Expected two deferred failures, got %d", handler.DeferredCount())
+	}
+	events := handler.DeferredEvents()
+	if len(events) != 2 || events[0].Message != "Row 1 Invalid" {
+		t.Fatalf("Unexpected deferred events: %+v", events)
+	}
+
+	count, err := handler.ProcessDeferredAssertions(context.TODO())
+	var assertionErr *AssertionError
+	if count != 2 || !errors.As(err, &assertionErr) {
+		t.Fatalf("Expected two processed failures and an AssertionError, got %d and %v", count, err)
+	}
+	if handler.DeferredCount() != 0 {
+		t.Fatalf("Expected processing to clear deferred failures")
+	}
+
+	handler.Assert(context.TODO(), false, "Row 3 Invalid")
+	if handler.ClearDeferred() != 1 || handler.DeferredCount() != 0 {
+		t.Fatalf("Expected ClearDeferred to discard pending failures")
+	}
+	if count, err := handler.ProcessDeferredAssertions(context.TODO()); count != 0 || err != nil || exits != 1 {
+		t.Fatalf("Expected nothing left to process")
+	}
+}
+
+func TestDeferredPolicies(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithDeferMode(true))
+
+	handler.With(WithDeferredPolicy(DeferredNeverExit)).Assert(context.TODO(), false, "Collected")
+	never := handler.With(WithDeferredPolicy(DeferredNeverExit))
+	if count, err := never.ProcessDeferredAssertions(context.TODO()); count != 1 || err == nil || exits != 0 {
+		t.Fatalf("Expected the never-exit policy to only return the aggregate")
+	}
+
+	onFatal := handler.With(WithDeferredPolicy(DeferredExitOnFatal))
+	onFatal.Assert(context.TODO(), false, "Plain Error")
+	onFatal.ProcessDeferredAssertions(context.TODO())
+	if exits != 0 {
+		t.Fatalf("Expected no exit without a fatal failure")
+	}
+	fatal := onFatal.With(WithErrorSeverityMapper(func(error) Severity { return SeverityFatal }))
+	fatal.NoError(context.TODO(), errors.New("corrupted"), "Fatal Failure")
+	onFatal.ProcessDeferredAssertions(context.TODO())
+	if exits != 1 {
+		t.Fatalf("Expected a fatal deferred failure to exit, got %d exits", exits)
+	}
+}
+
+func TestMaxDeferredFailsFast(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) { exits++ }),
+		WithDeferMode(true),
+		WithDeferredPolicy(DeferredNeverExit),
+		WithMaxDeferred(3),
+	)
+
+	for i := 0; i < 2; i++ {
+		handler.Assert(context.TODO(), false, "Row Invalid")
+	}
+	if exits != 0 {
+		t.Fatalf("Expected no exit below the maximum")
+	}
+
+	handler.Assert(context.TODO(), false, "Row Invalid")
+	if exits != 1 || handler.DeferredCount() != 0 {
+		t.Fatalf("Expected fail-fast processing at the maximum, got %d exits", exits)
+	}
+}
+
+func TestDeferUntil(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) { exits++ }),
+		WithDeferMode(true),
+		WithDeferredPolicy(DeferredNeverExit),
+		WithDeferUntil(2),
+	)
+	ctx := context.TODO()
+
+	handler.Assert(ctx, false, "Row Invalid")
+	if handler.DeferredCount() != 1 {
+		t.Fatalf("Expected the first failure to stay deferred")
+	}
+
+	handler.Assert(ctx, false, "Row Invalid")
+	if handler.DeferredCount() != 0 {
+		t.Fatalf("Expected the failures to be processed at the bound")
+	}
+	if exits != 0 {
+		t.Fatalf("Expected the deferred policy to decide about exiting, got %d exits", exits)
+	}
+
+	handler.Assert(ctx, false, "Row Invalid")
+	if handler.DeferredCount() != 1 {
+		t.Fatalf("Expected collection to continue after processing")
+	}
+
+	scoped, done := handler.BeginDeferredScope(ctx)
+	defer done()
+	handler.Assert(scoped, false, "Scoped Row Invalid")
+	handler.Assert(scoped, false, "Scoped Row Invalid")
+	if err := handler.ScopeError(scoped); err != nil || handler.DeferredCount() != 1 {
+		t.Fatalf("Expected the scope to be processed at the bound, got %v", err)
+	}
+}
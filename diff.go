@@ -0,0 +1,209 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// diffContext is how many unchanged lines are kept around each change in a line diff
+const diffContext = 3
+
+// maxDiffCells bounds the table of the longest common subsequence, the product of the line
+// counts left once the common prefix and suffix are trimmed. Larger inputs are not diffed.
+const maxDiffCells = 1 << 21
+
+// Equal fails when expected and actual are not deeply equal. Multi-line strings and structs
+// are reported as a diff instead of dumping both values.
+func (a *AssertHandler) Equal(ctx context.Context, expected, actual any, msg string, data ...any) {
+	ok, data := equal(expected, actual, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// Equal fails through the default handler when expected and actual are not deeply equal
+func Equal(ctx context.Context, expected, actual any, msg string, data ...any) {
+	ok, data := equal(expected, actual, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+func equal(expected, actual any, data []any) (bool, []any) {
+	if reflect.DeepEqual(expected, actual) {
+		return true, data
+	}
+	if e, a, ok := multiline(expected, actual); ok {
+		return false, diffData(data, e, a)
+	}
+	if diff := Diff(expected, actual); diff != "" {
+		return false, append(data, "diff", diff)
+	}
+	return false, append(data, "expected", expected, "actual", actual)
+}
+
+// Diff renders the difference between expected and actual: a unified line diff for
+// multi-line strings and one line per differing field for structs of the same type.
+// It returns "" for other values, which are best shown side by side.
+func Diff(expected, actual any) string {
+	if e, a, ok := multiline(expected, actual); ok {
+		diff, _ := lineDiff(strings.Split(e, "\n"), strings.Split(a, "\n"))
+		return diff
+	}
+	if _, ok := expected.(string); ok {
+		return ""
+	}
+
+	ev, av := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if !ev.IsValid() || !av.IsValid() || ev.Type() != av.Type() {
+		return ""
+	}
+	for ev.Kind() == reflect.Pointer && !ev.IsNil() && !av.IsNil() {
+		ev, av = ev.Elem(), av.Elem()
+	}
+	if ev.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var lines []string
+	fieldDiff(&lines, "", ev, av)
+	return strings.Join(lines, "\n")
+}
+
+// multiline returns expected and actual when both are strings and one has several lines
+func multiline(expected, actual any) (string, string, bool) {
+	e, ok := expected.(string)
+	if !ok {
+		return "", "", false
+	}
+	a, ok := actual.(string)
+	if !ok || !strings.Contains(e, "\n") && !strings.Contains(a, "\n") {
+		return "", "", false
+	}
+	return e, a, true
+}
+
+// diffData adds the line diff of expected and actual to data, or, when they are too large
+// to diff, the note lineDiff returns followed by both values
+func diffData(data []any, expected, actual string) []any {
+	diff, ok := lineDiff(strings.Split(expected, "\n"), strings.Split(actual, "\n"))
+	if !ok {
+		return append(data, "diff", diff, "expected", expected, "actual", actual)
+	}
+	return append(data, "diff", diff)
+}
+
+// fieldDiff appends a line for every differing field, descending into nested structs
+func fieldDiff(lines *[]string, path string, ev, av reflect.Value) {
+	for i := 0; i < ev.NumField(); i++ {
+		name := path + ev.Type().Field(i).Name
+		ef, af := ev.Field(i), av.Field(i)
+		if ef.Kind() == reflect.Struct {
+			fieldDiff(lines, name+".", ef, af)
+			continue
+		}
+
+		if !ef.CanInterface() {
+			// unexported fields can't be extracted, but fmt can still render them
+			if e, a := fmt.Sprint(ef), fmt.Sprint(af); e != a {
+				*lines = append(*lines, fmt.Sprintf("%s: -%s +%s", name, e, a))
+			}
+			continue
+		}
+		if !reflect.DeepEqual(ef.Interface(), af.Interface()) {
+			*lines = append(*lines, fmt.Sprintf("%s: -%s +%s", name, renderValue(ef.Interface()), renderValue(af.Interface())))
+		}
+	}
+}
+
+// lineDiff returns a unified diff of two line sequences based on their longest common
+// subsequence. When the lines left after trimming the common prefix and suffix are too many
+// to diff, it returns a note locating them instead and false.
+func lineDiff(expected, actual []string) (string, bool) {
+	prefix := 0
+	for prefix < len(expected) && prefix < len(actual) && expected[prefix] == actual[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(expected)-prefix && suffix < len(actual)-prefix &&
+		expected[len(expected)-1-suffix] == actual[len(actual)-1-suffix] {
+		suffix++
+	}
+	head := expected[:prefix]
+	tail := expected[len(expected)-suffix:]
+	expected, actual = expected[prefix:len(expected)-suffix], actual[prefix:len(actual)-suffix]
+
+	if (len(expected)+1)*(len(actual)+1) > maxDiffCells {
+		return fmt.Sprintf("--- expected\n+++ actual\n@@ diff too large: %d expected and %d actual lines differ from line %d",
+			len(expected), len(actual), prefix+1), false
+	}
+
+	// lcs[i][j] is the LCS length of expected[i:] and actual[j:]
+	lcs := make([][]int, len(expected)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type op struct {
+		mark byte
+		line string
+	}
+	var ops []op
+	for _, line := range head {
+		ops = append(ops, op{' ', line})
+	}
+	i, j := 0, 0
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case i < len(expected) && j < len(actual) && expected[i] == actual[j]:
+			ops = append(ops, op{' ', expected[i]})
+			i, j = i+1, j+1
+		case i < len(expected) && (j == len(actual) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{'-', expected[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', actual[j]})
+			j++
+		}
+	}
+
+	for _, line := range tail {
+		ops = append(ops, op{' ', line})
+	}
+
+	// keep diffContext unchanged lines around each change and elide the rest
+	keep := make([]bool, len(ops))
+	for k, o := range ops {
+		if o.mark == ' ' {
+			continue
+		}
+		for c := max(0, k-diffContext); c <= min(len(ops)-1, k+diffContext); c++ {
+			keep[c] = true
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("--- expected\n+++ actual")
+	elided := false
+	for k, o := range ops {
+		if !keep[k] {
+			if !elided {
+				b.WriteString("\n@@")
+			}
+			elided = true
+			continue
+		}
+		elided = false
+		b.WriteString("\n")
+		b.WriteByte(o.mark)
+		b.WriteString(o.line)
+	}
+	return b.String(), true
+}
@@ -0,0 +1,96 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+type address struct {
+	City string
+	Zip  string
+}
+
+type customer struct {
+	Name    string
+	Age     int
+	Address address
+	note    string
+}
+
+func TestDiffStructs(t *testing.T) {
+	want := customer{Name: "ada", Age: 36, Address: address{City: "London", Zip: "N1"}, note: "vip"}
+	got := customer{Name: "ada", Age: 37, Address: address{City: "Paris", Zip: "N1"}, note: "new"}
+
+	diff := Diff(&want, &got)
+	expected := "Age: -36 +37\nAddress.City: -London +Paris\nnote: -vip +new"
+	if diff != expected {
+		t.Fatalf("Unexpected struct diff:\n%s", diff)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	want := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj"
+	got := "a\nb\nc\nd\ne\nF\ng\nh\ni\nj\nk"
+
+	expected := strings.Join([]string{
+		"--- expected", "+++ actual", "@@",
+		" c", " d", " e", "-f", "+F", " g", " h", " i", " j", "+k",
+	}, "\n")
+	if diff := Diff(want, got); diff != expected {
+		t.Fatalf("Unexpected line diff:\n%s", diff)
+	}
+
+	if Diff("one line", "another") != "" || Diff(1, 2) != "" {
+		t.Fatalf("Expected no diff for single-line strings and scalars")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	handler.Equal(context.TODO(), []int{1, 2}, []int{1, 2}, "Equal Slices")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected equal values to pass, got %q", buffer.String())
+	}
+
+	handler.Equal(context.TODO(), 1, 2, "Scalars Differ")
+	if !strings.Contains(buffer.String(), "expected=1") || !strings.Contains(buffer.String(), "actual=2") {
+		t.Fatalf("Expected scalars side by side, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.Equal(context.TODO(), address{City: "London"}, address{City: "Paris"}, "Structs Differ")
+	if !strings.Contains(buffer.String(), "diff=City: -London +Paris") || strings.Contains(buffer.String(), "expected=") {
+		t.Fatalf("Expected a field diff instead of raw values, got %q", buffer.String())
+	}
+}
+
+func TestDiffLargePayloads(t *testing.T) {
+	lines := make([]string, 50000)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i%7)
+	}
+	want := strings.Join(lines, "\n")
+	lines[25000] = "changed"
+	got := strings.Join(lines, "\n")
+
+	diff := Diff(want, got)
+	if !strings.Contains(diff, "+changed") || strings.Count(diff, "\n") > 12 {
+		t.Fatalf("Expected the common prefix and suffix to be trimmed, got:\n%s", diff)
+	}
+
+	other := make([]string, 50000)
+	for i := range other {
+		other[i] = "y"
+	}
+	ok, data := equal(want, strings.Join(other, "\n"), nil)
+	fields := map[string]interface{}{}
+	appendArgs(fields, data)
+	note, _ := fields["diff"].(string)
+	if ok || !strings.Contains(note, "diff too large") || fields["expected"] == nil || fields["actual"] == nil {
+		t.Fatalf("Expected a note and both values for payloads too large to diff, got %q", note)
+	}
+}
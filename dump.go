@@ -0,0 +1,93 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"time"
+)
+
+// WithDumpOnFailure writes a heap profile and a dump of all goroutines to dir before the
+// exit function runs, for postmortem debugging of failures that terminate the process. The
+// files are named assert-<time>-<pid>-heap.pprof and assert-<time>-<pid>-goroutines.txt and
+// their paths are written to the handler's writer. An empty dir disables the dumps.
+func WithDumpOnFailure(dir string) Option {
+	return func(a *AssertHandler) {
+		a.dumpDir = dir
+	}
+}
+
+// WithCrashOutput makes the Go runtime write the report of crashes the handler never sees,
+// such as unrecovered panics and fatal runtime errors, to the file at path in addition to
+// standard error, as debug.SetCrashOutput does. The setting is process-wide.
+func WithCrashOutput(path string) Option {
+	return func(a *AssertHandler) {
+		if err := setCrashOutput(path); err != nil {
+			fmt.Fprintln(a.writer, "Crash output error:", err)
+		}
+	}
+}
+
+func setCrashOutput(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	// the runtime keeps its own duplicate of the descriptor
+	defer f.Close()
+	return debug.SetCrashOutput(f, debug.CrashOptions{})
+}
+
+// dumpProfiles writes the dumps configured by WithDumpOnFailure. Callers must hold flushLock.
+func (a *AssertHandler) dumpProfiles() {
+	if a.dumpDir == "" {
+		return
+	}
+	paths, err := writeDumps(a.dumpDir, time.Now())
+	for _, path := range paths {
+		fmt.Fprintln(a.writer, "Dump written:", path)
+	}
+	if err != nil {
+		fmt.Fprintln(a.writer, "Dump error:", err)
+	}
+}
+
+func writeDumps(dir string, now time.Time) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	prefix := filepath.Join(dir, fmt.Sprintf("assert-%s-%d", now.Format("20060102T150405.000"), os.Getpid()))
+
+	var paths []string
+	// an up to date heap profile needs the garbage collected since the last cycle
+	runtime.GC()
+	for _, dump := range []struct {
+		profile, suffix string
+		debug           int
+	}{
+		{"heap", "-heap.pprof", 0},
+		{"goroutine", "-goroutines.txt", 2},
+	} {
+		path := prefix + dump.suffix
+		if err := writeProfile(path, dump.profile, dump.debug); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeProfile(path, profile string, level int) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := pprof.Lookup(profile).WriteTo(f, level); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,138 @@
+package assert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// DumpState writes the handler's state as one indented JSON document for on-demand
+// diagnostics, e.g. from a debug endpoint or a SIGUSR1 handler: the configuration,
+// counters, suppressions and escalations, the latest failures, the registered AssertData
+// dumps and the stacks of all goroutines.
+func (a *AssertHandler) DumpState(ctx context.Context, w io.Writer) error {
+	a.flushLock.Lock()
+	config := a.stateConfig()
+	assertData := make(map[string]AssertData, len(a.assertData))
+	for k, v := range a.assertData {
+		assertData[k] = v
+	}
+	a.flushLock.Unlock()
+
+	// dumps run without the lock since they may take a while or assert themselves
+	dumps := make(map[string]string, len(assertData))
+	for k, v := range assertData {
+		dumps[k] = v.Dump()
+	}
+
+	recent := a.RecentEvents()
+	events := make([]SchemaEvent, len(recent))
+	for i, event := range recent {
+		events[i] = NewSchemaEvent(event)
+		events[i].Stack = ""
+		events[i].Frames = nil
+	}
+
+	hostname, _ := os.Hostname()
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"time":          time.Now().UTC().Format(time.RFC3339Nano),
+		"pid":           os.Getpid(),
+		"hostname":      hostname,
+		"config":        config,
+		"stats":         a.stateStats(),
+		"policy":        a.policy.snapshot(),
+		"recent_events": events,
+		"assert_data":   dumps,
+		"taxonomy":      a.Taxonomy(),
+		"goroutines":    string(allStacks()),
+	})
+}
+
+// stateConfig describes the handler's configuration. Callers must hold flushLock.
+func (a *AssertHandler) stateConfig() map[string]interface{} {
+	formatter := typeString(a.formatter)
+	if legacy, ok := a.formatter.(legacyFormatter); ok {
+		formatter = typeString(legacy.Formatter)
+	}
+	exporters := make([]string, len(a.exporters))
+	for i, e := range a.exporters {
+		exporters[i] = typeString(e)
+	}
+	writers := make([]string, len(a.writers))
+	for i, w := range a.writers {
+		writers[i] = typeString(w.w)
+	}
+	kindActions := make(map[string]int, len(a.kindActions))
+	for kind, action := range a.kindActions {
+		kindActions[string(kind)] = int(action)
+	}
+	routes := make(map[string]string, len(a.routes))
+	for kind, policy := range a.routes {
+		routes[string(kind)] = typeString(policy)
+	}
+	tags := map[string]interface{}{}
+	appendArgs(tags, a.tags)
+
+	return map[string]interface{}{
+		"formatter":         formatter,
+		"writer":            typeString(a.writer),
+		"writers":           writers,
+		"exporters":         exporters,
+		"tags":              tags,
+		"defer_assertions":  a.deferAssertions,
+		"deferred_policy":   int(a.deferredPolicy),
+		"max_deferred":      a.maxDeferred,
+		"defer_until":       a.deferUntil,
+		"kind_actions":      kindActions,
+		"report_first":      a.reportFirst,
+		"exit_panic":        a.exitPanic,
+		"failure_policy":    typeString(a.failurePolicy),
+		"routes":            routes,
+		"strict":            a.strictControlFlow,
+		"debug":             a.debugMode,
+		"isolated":          a.isolated,
+		"record_framing":    int(a.framing),
+		"flush_mode":        int(a.flushMode),
+		"crash_file":        a.crashFile,
+		"dump_dir":          a.dumpDir,
+		"spool_dir":         a.spoolDir,
+		"all_stacks":        a.allStacks,
+		"two_stage":         a.twoStage != nil,
+		"collection_budget": a.collectionBudget.String(),
+		"field_providers":   len(a.fieldProviders),
+		"failure_hooks":     len(a.onFailure),
+	}
+}
+
+// stateStats returns the handler's counters
+func (a *AssertHandler) stateStats() map[string]interface{} {
+	codes := a.historyCodes()
+	perCode := make(map[string]int, len(codes))
+	for _, code := range codes {
+		total := 0
+		for _, n := range a.History(code) {
+			total += n
+		}
+		perCode[code] = total
+	}
+	return map[string]interface{}{
+		"reported":            a.reported.Load(),
+		"deferred":            a.DeferredCount(),
+		"dropped_enrichments": a.DroppedEnrichments(),
+		"abandoned_flushes":   a.AbandonedFlushes(),
+		"failures_last_hour":  perCode,
+		"assertions":          a.Stats(),
+	}
+}
+
+func typeString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,59 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDumpState(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithMaxDeferred(5)).WithTags("service", "billing")
+	handler.AddAssertData("pool", staticData("3 idle"))
+	ctx := context.TODO()
+
+	handler.Suppress(ctx, "billing.noisy")
+	for i := 0; i < recentEvents+2; i++ {
+		handler.Assert(ctx, false, "Ledger Unbalanced", "code", "billing.ledger", "attempt", i)
+	}
+
+	var out bytes.Buffer
+	if err := handler.DumpState(ctx, &out); err != nil {
+		t.Fatalf("Failed to dump state: %v", err)
+	}
+	var state struct {
+		Config       map[string]any    `json:"config"`
+		Stats        map[string]any    `json:"stats"`
+		Policy       PolicyState       `json:"policy"`
+		RecentEvents []SchemaEvent     `json:"recent_events"`
+		AssertData   map[string]string `json:"assert_data"`
+		Goroutines   string            `json:"goroutines"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &state); err != nil {
+		t.Fatalf("Expected one JSON document, got %q: %v", out.String(), err)
+	}
+
+	if state.Config["max_deferred"] != float64(5) || state.Config["formatter"] != "*assert.TextFormatter" {
+		t.Fatalf("Expected the configuration, got %v", state.Config)
+	}
+	if tags := state.Config["tags"].(map[string]any); tags["service"] != "billing" {
+		t.Fatalf("Expected the tags in the configuration, got %v", state.Config["tags"])
+	}
+	if state.Stats["reported"] != float64(recentEvents+2) {
+		t.Fatalf("Expected the reported count, got %v", state.Stats)
+	}
+	if len(state.Policy.Suppressed) != 1 || state.Policy.Escalations["billing.ledger"] != recentEvents+2 {
+		t.Fatalf("Expected the policy state, got %+v", state.Policy)
+	}
+	if len(state.RecentEvents) != recentEvents || state.RecentEvents[0].Data["attempt"] != float64(2) || state.RecentEvents[0].Code != "billing.ledger" {
+		t.Fatalf("Expected the latest %d events, got %+v", recentEvents, state.RecentEvents)
+	}
+	if state.AssertData["pool"] != "3 idle" {
+		t.Fatalf("Expected the AssertData dumps, got %v", state.AssertData)
+	}
+	if !strings.Contains(state.Goroutines, "TestDumpState") {
+		t.Fatalf("Expected the goroutine stacks")
+	}
+}
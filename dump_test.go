@@ -0,0 +1,46 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestDumpOnFailure(t *testing.T) {
+	var buffer bytes.Buffer
+	dir := filepath.Join(t.TempDir(), "dumps")
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithDumpOnFailure(dir))
+
+	handler.Assert(context.TODO(), false, "Dumped")
+
+	heap, _ := filepath.Glob(filepath.Join(dir, "assert-*-heap.pprof"))
+	goroutines, _ := filepath.Glob(filepath.Join(dir, "assert-*-goroutines.txt"))
+	if len(heap) != 1 || len(goroutines) != 1 {
+		t.Fatalf("Expected a heap profile and a goroutine dump, got %v %v", heap, goroutines)
+	}
+	if info, err := os.Stat(heap[0]); err != nil || info.Size() == 0 {
+		t.Fatalf("Expected a non-empty heap profile: %v", err)
+	}
+	dump, _ := os.ReadFile(goroutines[0])
+	if !strings.Contains(string(dump), "TestDumpOnFailure") {
+		t.Fatalf("Expected the goroutine dump to contain the failing goroutine")
+	}
+	if !strings.Contains(buffer.String(), "Dump written: "+heap[0]) {
+		t.Fatalf("Expected the dump paths in the output, got %q", buffer.String())
+	}
+}
+
+func TestCrashOutput(t *testing.T) {
+	var buffer bytes.Buffer
+	path := filepath.Join(t.TempDir(), "crash.out")
+	NewAssertHandler(WithWriter(&buffer), WithCrashOutput(path))
+	defer debug.SetCrashOutput(nil, debug.CrashOptions{})
+
+	if _, err := os.Stat(path); err != nil || buffer.Len() != 0 {
+		t.Fatalf("Expected the crash output file to be set up: %v %q", err, buffer.String())
+	}
+}
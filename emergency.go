@@ -0,0 +1,81 @@
+package assert
+
+import (
+	"strconv"
+	"syscall"
+)
+
+// emergencyBufferSize bounds a single Emergency line; longer output is truncated
+const emergencyBufferSize = 2048
+
+// Emergency writes msg and data as one line straight to stderr with write(2), bypassing the
+// handler: no locks, no formatter, writer or exporters and no heap allocations beyond boxing
+// the arguments. It is safe to call from signal handlers, finalizers or while the normal
+// pipeline may be wedged, and never exits. Strings, integers and bools are rendered, errors
+// through their Error method; other values are written as "?".
+func (a *AssertHandler) Emergency(msg string, data ...any) {
+	Emergency(msg, data...)
+}
+
+// Emergency writes msg and data directly to stderr; see AssertHandler.Emergency
+func Emergency(msg string, data ...any) {
+	var buf [emergencyBufferSize]byte
+	syscall.Write(syscall.Stderr, appendEmergency(buf[:0], msg, data))
+}
+
+// appendEmergency renders the Emergency line into b without growing it
+func appendEmergency(b []byte, msg string, data []any) []byte {
+	b = appendTruncated(b, "ASSERT EMERGENCY msg=")
+	b = appendTruncated(b, msg)
+	for i := 0; i < len(data); i += 2 {
+		b = appendTruncated(b, " ")
+		if i+1 == len(data) {
+			b = appendTruncated(b, badKey+"=")
+			b = appendEmergencyValue(b, data[i])
+			break
+		}
+		b = appendEmergencyValue(b, data[i])
+		b = appendTruncated(b, "=")
+		b = appendEmergencyValue(b, data[i+1])
+	}
+	if len(b) == cap(b) {
+		b[len(b)-1] = '\n'
+		return b
+	}
+	return append(b, '\n')
+}
+
+// appendTruncated appends s to b without growing b beyond its capacity
+func appendTruncated[S string | []byte](b []byte, s S) []byte {
+	if room := cap(b) - len(b); len(s) > room {
+		s = s[:room]
+	}
+	return append(b, s...)
+}
+
+func appendEmergencyValue(b []byte, v any) []byte {
+	// strconv appends into a scratch array so the output stays bounded
+	var scratch [24]byte
+	switch v := v.(type) {
+	case string:
+		return appendTruncated(b, v)
+	case error:
+		return appendTruncated(b, v.Error())
+	case int:
+		return appendTruncated(b, strconv.AppendInt(scratch[:0], int64(v), 10))
+	case int64:
+		return appendTruncated(b, strconv.AppendInt(scratch[:0], v, 10))
+	case int32:
+		return appendTruncated(b, strconv.AppendInt(scratch[:0], int64(v), 10))
+	case uint:
+		return appendTruncated(b, strconv.AppendUint(scratch[:0], uint64(v), 10))
+	case uint64:
+		return appendTruncated(b, strconv.AppendUint(scratch[:0], v, 10))
+	case uint32:
+		return appendTruncated(b, strconv.AppendUint(scratch[:0], uint64(v), 10))
+	case bool:
+		return appendTruncated(b, strconv.FormatBool(v))
+	default:
+		return appendTruncated(b, "?")
+	}
+}
@@ -0,0 +1,31 @@
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEmergencyLine(t *testing.T) {
+	var buf [emergencyBufferSize]byte
+	line := string(appendEmergency(buf[:0], "Wedged", []any{"signal", "SIGTERM", "pid", 42, "ok", false, "err", errors.New("stuck"), "obj", struct{}{}, "orphan"}))
+
+	expected := "ASSERT EMERGENCY msg=Wedged signal=SIGTERM pid=42 ok=false err=stuck obj=? !BADKEY=orphan\n"
+	if line != expected {
+		t.Fatalf("Unexpected emergency line: %q", line)
+	}
+
+	long := appendEmergency(buf[:0], strings.Repeat("x", 2*emergencyBufferSize), nil)
+	if len(long) != emergencyBufferSize || long[len(long)-1] != '\n' {
+		t.Fatalf("Expected long output to be truncated to the buffer, got %d bytes", len(long))
+	}
+
+	data := []any{"pid", 42, "signal", "SIGTERM"}
+	allocs := testing.AllocsPerRun(100, func() {
+		var buf [emergencyBufferSize]byte
+		appendEmergency(buf[:0], "Wedged", data)
+	})
+	if allocs != 0 {
+		t.Fatalf("Expected no allocations, got %v", allocs)
+	}
+}
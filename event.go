@@ -0,0 +1,23 @@
+package assert
+
+import (
+	"runtime"
+	"time"
+)
+
+// AssertionEvent is the structured form of a failed assertion handed to formatters and exporters
+type AssertionEvent struct {
+	Time     time.Time
+	Severity Severity
+	// Kind is the kind of the failed assertion, KindAssert for plain assertions
+	Kind    Kind
+	Message string
+	Data    map[string]interface{}
+	Stack   string
+	// Caller is the file:line of the code that called the assertion
+	Caller string
+	// Fingerprint groups the occurrences of the same failure, see the "fingerprint" data key
+	Fingerprint string
+	// Frames is the stack starting at the caller
+	Frames []runtime.Frame
+}
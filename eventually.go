@@ -0,0 +1,58 @@
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+// eventuallyRecentErrors bounds how many intermediate errors NoErrorEventually reports
+const eventuallyRecentErrors = 5
+
+// NoErrorEventually calls fn every interval until it returns nil or timeout elapses.
+// On failure the attempt count and the most recent errors are added to the failure data. When
+// ctx is canceled first, the last error is still reported, with the cancellation cause.
+func (a *AssertHandler) NoErrorEventually(ctx context.Context, fn func() error, timeout, interval time.Duration, msg string, data ...any) {
+	deadline := time.Now().Add(timeout)
+	attempts := 0
+	recent := []string{}
+
+	var err error
+	for {
+		attempts++
+		if err = fn(); err == nil {
+			a.passed(KindAssert)
+			return
+		}
+
+		recent = append(recent, err.Error())
+		if len(recent) > eventuallyRecentErrors {
+			recent = recent[1:]
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			break
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+			continue
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		break
+	}
+
+	data = append(data, "error", err, "attempts", attempts, "recent_errors", recent)
+	if ctx.Err() != nil {
+		// report would drop the failure as canceled; the last error is what explains it
+		data = append(data, "canceled", context.Cause(ctx))
+		ctx = context.WithoutCancel(ctx)
+	}
+	a.runAssertWithSeverity(ctx, a.severityForError(err), msg, data...)
+}
+
+// NoErrorEventually retries fn until it succeeds or timeout elapses, through the default handler
+func NoErrorEventually(ctx context.Context, fn func() error, timeout, interval time.Duration, msg string, data ...any) {
+	handlerFor(ctx).NoErrorEventually(ctx, fn, timeout, interval, msg, data...)
+}
@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNoErrorEventuallySucceeds(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {
+		t.Fatalf("Expected no failure")
+	}))
+
+	calls := 0
+	handler.NoErrorEventually(context.TODO(), func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("not ready")
+		}
+		return nil
+	}, time.Second, time.Millisecond, "Dependency Never Ready")
+
+	if calls != 3 {
+		t.Fatalf("Expected three attempts, got %d", calls)
+	}
+}
+
+func TestNoErrorEventuallyRecordsAttempts(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+
+	calls := 0
+	handler.NoErrorEventually(context.TODO(), func() error {
+		calls++
+		return fmt.Errorf("attempt %d failed", calls)
+	}, 20*time.Millisecond, time.Millisecond, "Dependency Never Ready")
+
+	if exits != 1 {
+		t.Fatalf("Expected one failure, got %d", exits)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte(fmt.Sprintf("attempts=%d", calls))) {
+		t.Fatalf("Expected attempt count in output")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte(fmt.Sprintf("attempt %d failed", calls))) {
+		t.Fatalf("Expected last error in recent errors")
+	}
+}
+
+func TestNoErrorEventuallyCanceled(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithStats())
+
+	handler.NoErrorEventually(context.TODO(), func() error { return nil }, time.Second, time.Millisecond, "Ready")
+	if stats := handler.Stats(); stats.Passed != 1 {
+		t.Fatalf("Expected the successful retry to count as a pass, got %+v", stats.Counts)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	attempts := 0
+	handler.NoErrorEventually(ctx, func() error {
+		if attempts++; attempts == 2 {
+			cancel()
+		}
+		return errors.New("still starting")
+	}, time.Minute, time.Millisecond, "Service Ready")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("error=still starting")) || !bytes.Contains(buffer.Bytes(), []byte("canceled=context canceled")) {
+		t.Fatalf("Expected the last error to be reported on cancellation, got %q", buffer.String())
+	}
+}
@@ -13,7 +13,7 @@ func main() {
 	handler.SetDeferAssertions(true)
 
 	// Multiple assertions that will not fail immediately
-	handler.Nil(context.TODO(), nil, "Deferred Nil Assertion")
+	handler.Nil(context.TODO(), "not nil", "Deferred Nil Assertion")
 	handler.Assert(context.TODO(), false, "Deferred Assert Failure")
 
 	// Process all deferred assertions (will print all errors and exit)
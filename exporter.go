@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Exporter ships assertion events to an external backend (webhook, Sentry, OTLP, Kafka, ...).
+//
+// Export receives events in batches; a handler exports each failure as a batch of one,
+// while wrappers such as a batching layer may group several events into a single call.
+// Implementations must be safe for concurrent use. Shutdown flushes anything still
+// buffered and releases resources; Export must not be called after Shutdown.
+type Exporter interface {
+	Export(ctx context.Context, events []AssertionEvent) error
+	Shutdown(ctx context.Context) error
+}
+
+// AddExporter registers an exporter that receives every assertion event
+func (a *AssertHandler) AddExporter(exporter Exporter) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	a.exporters = append(a.exporters, exporter)
+}
+
+// Shutdown emits the failures waiting for enrichment, flushes buffering writers, saves the
+// policy state and shuts down all registered exporters, returning their joined errors
+func (a *AssertHandler) Shutdown(ctx context.Context) error {
+	var errs []error
+	a.flushLock.Lock()
+	exporters := append([]Exporter(nil), a.exporters...)
+	if a.twoStage != nil {
+		if err := a.twoStage.flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	a.flushWriters(ctx)
+	a.flushLock.Unlock()
+
+	if err := a.SaveState(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	for _, e := range exporters {
+		if err := e.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// export must be called with flushLock held
+func (a *AssertHandler) export(ctx context.Context, event AssertionEvent) {
+	batch := []AssertionEvent{event}
+	for _, e := range a.exporters {
+		if err := e.Export(ctx, batch); err != nil {
+			fmt.Fprintln(a.writer, "Exporter error:", err)
+		}
+	}
+}
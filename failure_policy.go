@@ -0,0 +1,108 @@
+package assert
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// FailurePolicy decides what happens once a fatal failure has been reported, or once
+// deferred failures have been processed, in which case event summarizes them all.
+// Policies compose with ChainPolicy, e.g. logging, counting a metric and then panicking.
+type FailurePolicy interface {
+	Handle(event AssertionEvent)
+}
+
+// WithFailurePolicy sets the policy run after fatal failures. The default is ExitPolicy{}.
+func WithFailurePolicy(policy FailurePolicy) Option {
+	return func(a *AssertHandler) {
+		a.failurePolicy = policy
+	}
+}
+
+// SetFailurePolicy sets the policy run after fatal failures
+func (a *AssertHandler) SetFailurePolicy(policy FailurePolicy) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	a.failurePolicy = policy
+}
+
+// ExitPolicy exits the process with Code, or 1 if Code is zero, through Exit, or os.Exit if
+// Exit is nil
+type ExitPolicy struct {
+	Code int
+	Exit func(code int)
+}
+
+func (p ExitPolicy) Handle(event AssertionEvent) {
+	code := p.Code
+	if code == 0 {
+		code = 1
+	}
+	exit := p.Exit
+	if exit == nil {
+		exit = os.Exit
+	}
+	exit(code)
+}
+
+// PanicPolicy panics with the *AssertionError of the failure; for deferred failures its
+// Failures field holds each of them
+type PanicPolicy struct{}
+
+func (PanicPolicy) Handle(event AssertionEvent) {
+	panic(newAssertionError(event))
+}
+
+// LogOnlyPolicy does nothing beyond the report already written, so execution continues
+type LogOnlyPolicy struct{}
+
+func (LogOnlyPolicy) Handle(AssertionEvent) {}
+
+// ErrorPolicy collects failures as errors so execution continues and the caller decides
+// what to do with them, e.g. returning Err from a request handler. Use it as a pointer.
+type ErrorPolicy struct {
+	mu       sync.Mutex
+	failures []*AssertionError
+}
+
+func (p *ErrorPolicy) Handle(event AssertionEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failures = append(p.failures, newAssertionError(event))
+}
+
+// Err returns the collected failures joined with errors.Join, or nil if there were none
+func (p *ErrorPolicy) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	errs := make([]error, len(p.failures))
+	for i, f := range p.failures {
+		errs[i] = f
+	}
+	return errors.Join(errs...)
+}
+
+// Reset forgets the collected failures
+func (p *ErrorPolicy) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failures = nil
+}
+
+// ChainPolicy runs its policies in order. A policy that exits or panics ends the chain, so
+// it belongs last.
+type ChainPolicy []FailurePolicy
+
+func (c ChainPolicy) Handle(event AssertionEvent) {
+	for _, p := range c {
+		p.Handle(event)
+	}
+}
+
+// FailurePolicyFunc adapts a function to a FailurePolicy
+type FailurePolicyFunc func(event AssertionEvent)
+
+func (f FailurePolicyFunc) Handle(event AssertionEvent) {
+	f(event)
+}
@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFailurePolicies(t *testing.T) {
+	var buffer bytes.Buffer
+	code := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithFailurePolicy(ExitPolicy{Code: 3, Exit: func(c int) { code = c }}))
+	handler.Assert(context.TODO(), false, "Exit Code")
+	if code != 3 {
+		t.Fatalf("Expected the exit policy to exit with its code, got %d", code)
+	}
+
+	handler.With(WithFailurePolicy(LogOnlyPolicy{})).Assert(context.TODO(), false, "Logged Only")
+	if !bytes.Contains(buffer.Bytes(), []byte("Logged Only")) {
+		t.Fatalf("Expected the log-only policy to keep the report")
+	}
+
+	errs := &ErrorPolicy{}
+	collecting := handler.With(WithFailurePolicy(errs))
+	collecting.Assert(context.TODO(), false, "First Failure")
+	collecting.Assert(context.TODO(), false, "Second Failure")
+	var failure *AssertionError
+	if err := errs.Err(); !errors.As(err, &failure) || failure.Msg != "First Failure" {
+		t.Fatalf("Expected the error policy to collect the failures, got %v", err)
+	}
+	if errs.Reset(); errs.Err() != nil {
+		t.Fatalf("Expected Reset to forget the failures")
+	}
+}
+
+func TestChainPolicy(t *testing.T) {
+	var buffer bytes.Buffer
+	var seen []string
+	metric := FailurePolicyFunc(func(event AssertionEvent) { seen = append(seen, event.Message) })
+	handler := NewAssertHandler(WithWriter(&buffer), WithFailurePolicy(ChainPolicy{metric, PanicPolicy{}}))
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		handler.Assert(context.TODO(), false, "Ledger Balanced")
+	}()
+	if err, ok := recovered.(*AssertionError); !ok || err.Msg != "Ledger Balanced" || len(seen) != 1 {
+		t.Fatalf("Expected the metric policy to run before the panic, got %v and %v", seen, recovered)
+	}
+
+	deferred := handler.With(WithDeferMode(true))
+	deferred.Assert(context.TODO(), false, "Collected One")
+	deferred.Assert(context.TODO(), false, "Collected Two")
+	func() {
+		defer func() { recovered = recover() }()
+		deferred.ProcessDeferredAssertions(context.TODO())
+	}()
+	if err, ok := recovered.(*AssertionError); !ok || len(err.Failures) != 2 || seen[1] != "deferred assertions failed" {
+		t.Fatalf("Expected the policy to handle the deferred summary, got %v and %v", seen, recovered)
+	}
+}
@@ -0,0 +1,123 @@
+// Package fakes provides in-memory Exporter implementations for testing
+// assertion wiring without real backends.
+package fakes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// ErrExport is the default error returned by FailingExporter
+var ErrExport = errors.New("fakes: export failed")
+
+// RecordingExporter keeps every exported event in memory
+type RecordingExporter struct {
+	mu       sync.Mutex
+	events   []assert.AssertionEvent
+	batches  int
+	shutdown bool
+}
+
+func (r *RecordingExporter) Export(ctx context.Context, events []assert.AssertionEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+	r.batches++
+	return nil
+}
+
+func (r *RecordingExporter) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shutdown = true
+	return nil
+}
+
+// Events returns a copy of all recorded events
+func (r *RecordingExporter) Events() []assert.AssertionEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]assert.AssertionEvent(nil), r.events...)
+}
+
+// Batches returns how many Export calls were received
+func (r *RecordingExporter) Batches() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches
+}
+
+// IsShutdown reports whether Shutdown has been called
+func (r *RecordingExporter) IsShutdown() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.shutdown
+}
+
+// FailingExporter rejects every batch with Err (or ErrExport when nil)
+type FailingExporter struct {
+	Err error
+
+	mu       sync.Mutex
+	attempts int
+}
+
+func (f *FailingExporter) Export(ctx context.Context, events []assert.AssertionEvent) error {
+	f.mu.Lock()
+	f.attempts++
+	f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	return ErrExport
+}
+
+func (f *FailingExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// Attempts returns how many Export calls were received
+func (f *FailingExporter) Attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+// SlowExporter delays every call by Delay before forwarding to Next (if set).
+// The delay is cut short when the context is done.
+type SlowExporter struct {
+	Delay time.Duration
+	Next  assert.Exporter
+}
+
+func (s *SlowExporter) Export(ctx context.Context, events []assert.AssertionEvent) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+	if s.Next != nil {
+		return s.Next.Export(ctx, events)
+	}
+	return nil
+}
+
+func (s *SlowExporter) Shutdown(ctx context.Context) error {
+	if s.Next != nil {
+		return s.Next.Shutdown(ctx)
+	}
+	return nil
+}
+
+func (s *SlowExporter) wait(ctx context.Context) error {
+	timer := time.NewTimer(s.Delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
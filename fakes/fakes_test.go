@@ -0,0 +1,63 @@
+package fakes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+func TestRecordingExporter(t *testing.T) {
+	var buffer bytes.Buffer
+	recorder := &RecordingExporter{}
+	handler := assert.NewAssertHandler()
+	handler.ToWriter(&buffer)
+	handler.SetExitFunc(func(code int) {})
+	handler.AddExporter(recorder)
+
+	handler.Assert(context.TODO(), false, "Test Failure")
+
+	events := recorder.Events()
+	if len(events) != 1 || events[0].Message != "Test Failure" {
+		t.Fatalf("Expected one recorded event, got %+v", events)
+	}
+
+	if err := handler.Shutdown(context.TODO()); err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+	if !recorder.IsShutdown() {
+		t.Fatalf("Expected recorder to be shut down")
+	}
+}
+
+func TestFailingExporter(t *testing.T) {
+	var buffer bytes.Buffer
+	failing := &FailingExporter{}
+	handler := assert.NewAssertHandler()
+	handler.ToWriter(&buffer)
+	handler.SetExitFunc(func(code int) {})
+	handler.AddExporter(failing)
+
+	handler.Assert(context.TODO(), false, "Test Failure")
+
+	if failing.Attempts() != 1 {
+		t.Fatalf("Expected one export attempt, got %d", failing.Attempts())
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte(ErrExport.Error())) {
+		t.Fatalf("Expected exporter error in output")
+	}
+}
+
+func TestSlowExporterHonorsContext(t *testing.T) {
+	slow := &SlowExporter{Delay: time.Second}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	err := slow.Export(ctx, []assert.AssertionEvent{{Message: "slow"}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+}
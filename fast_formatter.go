@@ -0,0 +1,193 @@
+package assert
+
+import (
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxFastFields bounds FastFormatter.MaxFields so the selected keys fit on the stack
+const maxFastFields = 16
+
+// FastFormatter renders a failure as a single logfmt line — msg, code, fingerprint and caller
+// followed by up to MaxFields scalar fields in key order — without reflection or intermediate
+// maps, for services reporting record-only assertions at a high rate. Values other than strings,
+// numbers, booleans, durations, times and errors are skipped, and so is the stack. Pair it
+// with WithRecordFraming(FramingNewline).
+type FastFormatter struct {
+	// MaxFields bounds the additional fields written, 8 if zero, at most 16
+	MaxFields int
+	// MaxValueLen truncates longer string values, 256 if zero
+	MaxValueLen int
+}
+
+var fastBuffers = sync.Pool{New: func() any {
+	b := make([]byte, 0, 512)
+	return &b
+}}
+
+func (f *FastFormatter) Format(assertData map[string]interface{}, stack string) string {
+	bp := fastBuffers.Get().(*[]byte)
+	msg, _ := assertData["msg"].(string)
+	caller, _ := assertData["caller"].(string)
+	b := f.appendEvent((*bp)[:0], msg, caller, assertData)
+	out := string(b)
+	*bp = b
+	fastBuffers.Put(bp)
+	return out
+}
+
+func (f *FastFormatter) FormatEvent(event AssertionEvent) ([]byte, error) {
+	bp := fastBuffers.Get().(*[]byte)
+	msg := event.Message
+	if msg == "" {
+		msg, _ = event.Data["msg"].(string)
+	}
+	caller := event.Caller
+	if caller == "" {
+		caller, _ = event.Data["caller"].(string)
+	}
+	b := f.appendEvent((*bp)[:0], msg, caller, event.Data)
+	out := append([]byte(nil), b...)
+	*bp = b
+	fastBuffers.Put(bp)
+	return out, nil
+}
+
+func (f *FastFormatter) appendEvent(b []byte, msg, caller string, data map[string]interface{}) []byte {
+	b = append(b, "msg="...)
+	b = f.appendString(b, msg)
+	if code, ok := data["code"]; ok {
+		b = append(b, " code="...)
+		b = f.appendScalar(b, code)
+	}
+	if fp, ok := data[fingerprintKey]; ok {
+		b = append(b, " fingerprint="...)
+		b = f.appendScalar(b, fp)
+	}
+	if caller != "" {
+		b = append(b, " caller="...)
+		b = f.appendString(b, caller)
+	}
+
+	// pick the first keys in sorted order with an insertion sort over a fixed array
+	limit := f.MaxFields
+	if limit <= 0 {
+		limit = 8
+	}
+	limit = min(limit, maxFastFields)
+	var keys [maxFastFields]string
+	n := 0
+	for k, v := range data {
+		if k == "msg" || k == "code" || k == fingerprintKey || k == "caller" || k == "stack" || !isScalar(v) {
+			continue
+		}
+		if n == limit && k >= keys[n-1] {
+			continue
+		}
+		i := min(n, limit-1)
+		for i > 0 && keys[i-1] > k {
+			keys[i] = keys[i-1]
+			i--
+		}
+		keys[i] = k
+		n = min(n+1, limit)
+	}
+
+	for _, k := range keys[:n] {
+		b = append(b, ' ')
+		b = appendFastKey(b, k)
+		b = append(b, '=')
+		b = f.appendScalar(b, data[k])
+	}
+	return b
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, time.Duration, time.Time, error:
+		return true
+	}
+	return false
+}
+
+// appendScalar writes a scalar value, and ? for anything else
+func (f *FastFormatter) appendScalar(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case string:
+		return f.appendString(b, v)
+	case bool:
+		return strconv.AppendBool(b, v)
+	case int:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(b, v, 10)
+	case float32:
+		return strconv.AppendFloat(b, float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(b, v, 'g', -1, 64)
+	case time.Duration:
+		return f.appendString(b, v.String())
+	case time.Time:
+		return v.AppendFormat(b, time.RFC3339Nano)
+	case error:
+		return f.appendString(b, v.Error())
+	}
+	return append(b, '?')
+}
+
+// appendString writes s bare when it is safe to, and quoted otherwise
+func (f *FastFormatter) appendString(b []byte, s string) []byte {
+	limit := f.MaxValueLen
+	if limit <= 0 {
+		limit = 256
+	}
+	truncated := len(s) > limit
+	if truncated {
+		s = s[:limit]
+	}
+	if s == "" || truncated || needsQuote(s) {
+		b = strconv.AppendQuote(b, s)
+		if truncated {
+			b = append(b[:len(b)-1], `..."`...)
+		}
+		return b
+	}
+	return append(b, s...)
+}
+
+// appendFastKey writes a key, quoted when it contains characters that would break the line
+func appendFastKey(b []byte, k string) []byte {
+	if k == "" || needsQuote(k) {
+		return strconv.AppendQuote(b, k)
+	}
+	return append(b, k...)
+}
+
+func needsQuote(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c == '=' || c == '"' || c == 0x7f || c >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package assert
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func fastSample() AssertionEvent {
+	return AssertionEvent{
+		Time:     time.Now(),
+		Severity: SeverityWarn,
+		Message:  "Queue Backlog",
+		Caller:   "worker.go:42",
+		Data: map[string]interface{}{
+			"msg":      "Queue Backlog",
+			"code":     "Q-7",
+			"caller":   "worker.go:42",
+			"severity": "WARN",
+			"area":     "Assert",
+			"depth":    1200,
+			"limit":    1000,
+			"ratio":    1.2,
+			"queue":    "ingest events",
+			"elapsed":  1500 * time.Millisecond,
+			"err":      errors.New("too slow"),
+			"nested":   map[string]interface{}{"skipped": true},
+		},
+	}
+}
+
+func TestFastFormatter(t *testing.T) {
+	out, err := (&FastFormatter{}).FormatEvent(fastSample())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `msg="Queue Backlog" code=Q-7 caller=worker.go:42 area=Assert depth=1200 elapsed=1.5s err="too slow" limit=1000 queue="ingest events" ratio=1.2 severity=WARN`
+	if string(out) != expected {
+		t.Fatalf("Unexpected output:\n%s\n%s", out, expected)
+	}
+
+	limited := (&FastFormatter{MaxFields: 2, MaxValueLen: 4}).Format(fastSample().Data, "stack")
+	if limited != `msg="Queu..." code=Q-7 caller="work..." area="Asse..." depth=1200` {
+		t.Fatalf("Expected the fields and values to be bounded, got %s", limited)
+	}
+
+	odd := (&FastFormatter{}).Format(map[string]interface{}{"msg": "a\nb", "k=v": `"q"`}, "")
+	if odd != `msg="a\nb" "k=v"="\"q\""` {
+		t.Fatalf("Expected special characters to be quoted, got %s", odd)
+	}
+}
+
+func BenchmarkFastFormatter(b *testing.B) {
+	event := fastSample()
+	f := &FastFormatter{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.FormatEvent(event)
+	}
+}
+
+func BenchmarkJSONFormatter(b *testing.B) {
+	event := fastSample()
+	f := &JSONFormatter{Compact: true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.FormatEvent(event)
+	}
+}
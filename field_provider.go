@@ -0,0 +1,58 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+)
+
+// KV is a single key/value field
+type KV struct {
+	Key   string
+	Value any
+}
+
+// FieldProvider contributes computed fields to every failure when it is reported, e.g. the
+// active trace ID or the state of a feature flag. The event passed in carries the data
+// gathered so far; returned fields never override keys already present.
+type FieldProvider interface {
+	Fields(ctx context.Context, event AssertionEvent) []KV
+}
+
+// FieldProviderFunc adapts a function to FieldProvider
+type FieldProviderFunc func(ctx context.Context, event AssertionEvent) []KV
+
+func (f FieldProviderFunc) Fields(ctx context.Context, event AssertionEvent) []KV {
+	return f(ctx, event)
+}
+
+// WithFieldProvider registers a provider of computed fields
+func WithFieldProvider(provider FieldProvider) Option {
+	return func(a *AssertHandler) {
+		a.fieldProviders = append(a.fieldProviders, provider)
+	}
+}
+
+// AddFieldProvider registers a provider of computed fields
+func (a *AssertHandler) AddFieldProvider(provider FieldProvider) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	a.fieldProviders = append(a.fieldProviders, provider)
+}
+
+// provideFields adds the fields of every provider to event.Data, skipping providers that
+// exceed the collection budget. Callers must hold flushLock.
+func (a *AssertHandler) provideFields(ctx context.Context, event AssertionEvent, budget *collectionBudget) {
+	for i, provider := range a.fieldProviders {
+		var fields []KV
+		snapshot := event
+		snapshot.Data = budget.snapshot(event.Data)
+		if !budget.run(fmt.Sprintf("field_provider.%d", i), func() { fields = provider.Fields(ctx, snapshot) }) {
+			continue
+		}
+		for _, kv := range fields {
+			if _, ok := event.Data[kv.Key]; !ok {
+				event.Data[kv.Key] = kv.Value
+			}
+		}
+	}
+}
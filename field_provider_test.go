@@ -0,0 +1,32 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+type traceKey struct{}
+
+func TestFieldProvider(t *testing.T) {
+	var buffer bytes.Buffer
+	tracing := FieldProviderFunc(func(ctx context.Context, event AssertionEvent) []KV {
+		trace, _ := ctx.Value(traceKey{}).(string)
+		return []KV{{Key: "trace_id", Value: trace}, {Key: "msg", Value: "overridden"}}
+	})
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFieldProvider(tracing))
+	handler.AddFieldProvider(FieldProviderFunc(func(ctx context.Context, event AssertionEvent) []KV {
+		return []KV{{Key: "seen_message", Value: event.Message}}
+	}))
+
+	ctx := context.WithValue(context.TODO(), traceKey{}, "abc123")
+	handler.With().Assert(ctx, false, "Provided Fields")
+
+	output := buffer.String()
+	for _, expected := range []string{"trace_id=abc123", "seen_message=Provided Fields", "msg=Provided Fields"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected %q in output, got %s", expected, output)
+		}
+	}
+}
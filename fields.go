@@ -0,0 +1,27 @@
+package assert
+
+// FieldSet groups key/value pairs passed to an assertion call
+type FieldSet []any
+
+// Fields bundles key/value pairs so they can be passed alongside per-call options:
+//
+//	assert.Assert(ctx, ok, "quota exceeded", assert.Fields("user", id), assert.WithDebugMode())
+func Fields(kv ...any) FieldSet {
+	return FieldSet(kv)
+}
+
+// splitCallArgs separates the variadic data of an assertion call into plain key/value
+// pairs and per-call options. FieldSet values are flattened in place.
+func splitCallArgs(args []any) (kv []any, opts []Option) {
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case Option:
+			opts = append(opts, v)
+		case FieldSet:
+			kv = append(kv, v...)
+		default:
+			kv = append(kv, arg)
+		}
+	}
+	return kv, opts
+}
@@ -0,0 +1,38 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestFieldsAndPerCallOptions(t *testing.T) {
+	defer SetDefaultHandler(nil)
+	SetDefaultHandler(nil)
+
+	var buffer bytes.Buffer
+	exits := 0
+	Configure(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+
+	Assert(context.TODO(), false, "Quota Exceeded", Fields("user", "u-1", "quota", 10), "plan", "free", WithDeferMode(true), WithDebugMode())
+
+	if exits != 0 {
+		t.Fatalf("Expected the per-call defer option to defer the failure")
+	}
+	for _, want := range []string{"user=u-1", "quota=10", "plan=free", "ARGS:"} {
+		if !bytes.Contains(buffer.Bytes(), []byte(want)) {
+			t.Fatalf("Expected %q in output, got %s", want, buffer.String())
+		}
+	}
+
+	ProcessDeferredAssertions(context.TODO())
+	if exits != 1 {
+		t.Fatalf("Expected the deferred failure to reach the default handler")
+	}
+
+	buffer.Reset()
+	Assert(context.TODO(), false, "Plain Failure")
+	if bytes.Contains(buffer.Bytes(), []byte("ARGS:")) || exits != 2 {
+		t.Fatalf("Expected per-call options not to persist")
+	}
+}
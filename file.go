@@ -0,0 +1,212 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WithFileSystem makes the file assertions read from fsys instead of the operating system,
+// e.g. an fstest.MapFS in tests or an embed.FS. Paths are then fs.FS paths, without a
+// leading slash.
+func WithFileSystem(fsys fs.FS) Option {
+	return func(a *AssertHandler) {
+		a.fileSystem = fsys
+	}
+}
+
+// fileSystemer is implemented by Asserters configured with a file system
+type fileSystemer interface {
+	fileSystemFor() fs.FS
+}
+
+func (a *AssertHandler) fileSystemFor() fs.FS {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	if a.fileSystem == nil {
+		return osFS{}
+	}
+	return a.fileSystem
+}
+
+// fileSystemOf returns the file system of a, the operating system's for other Asserters
+func fileSystemOf(a Asserter) fs.FS {
+	if f, ok := a.(fileSystemer); ok {
+		return f.fileSystemFor()
+	}
+	return osFS{}
+}
+
+// FileExists fails when path does not exist or is a directory
+func (a *AssertHandler) FileExists(ctx context.Context, path string, msg string, data ...any) {
+	ok, data := fileExists(a.fileSystemFor(), path, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// FileExists fails through the default handler when path does not exist or is a directory
+func FileExists(ctx context.Context, path string, msg string, data ...any) {
+	a := handlerFor(ctx)
+	ok, data := fileExists(fileSystemOf(a), path, data)
+	a.Assert(ctx, ok, msg, data...)
+}
+
+// DirExists fails when path does not exist or is not a directory
+func (a *AssertHandler) DirExists(ctx context.Context, path string, msg string, data ...any) {
+	ok, data := dirExists(a.fileSystemFor(), path, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// DirExists fails through the default handler when path does not exist or is not a directory
+func DirExists(ctx context.Context, path string, msg string, data ...any) {
+	a := handlerFor(ctx)
+	ok, data := dirExists(fileSystemOf(a), path, data)
+	a.Assert(ctx, ok, msg, data...)
+}
+
+// FileMode fails when path does not exist or its permission bits are not those of perm
+func (a *AssertHandler) FileMode(ctx context.Context, path string, perm fs.FileMode, msg string, data ...any) {
+	ok, data := fileMode(a.fileSystemFor(), path, perm, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// FileMode fails through the default handler when the permission bits of path are not perm
+func FileMode(ctx context.Context, path string, perm fs.FileMode, msg string, data ...any) {
+	a := handlerFor(ctx)
+	ok, data := fileMode(fileSystemOf(a), path, perm, data)
+	a.Assert(ctx, ok, msg, data...)
+}
+
+// FileContains fails when path cannot be read or does not contain substr
+func (a *AssertHandler) FileContains(ctx context.Context, path, substr string, msg string, data ...any) {
+	ok, data := fileContains(a.fileSystemFor(), path, substr, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// FileContains fails through the default handler when path does not contain substr
+func FileContains(ctx context.Context, path, substr string, msg string, data ...any) {
+	a := handlerFor(ctx)
+	ok, data := fileContains(fileSystemOf(a), path, substr, data)
+	a.Assert(ctx, ok, msg, data...)
+}
+
+// FileEqual fails when pathA and pathB cannot be read or differ in content. Text files are
+// reported with a line diff, other files with the offset of the first differing byte.
+func (a *AssertHandler) FileEqual(ctx context.Context, pathA, pathB string, msg string, data ...any) {
+	ok, data := fileEqual(a.fileSystemFor(), pathA, pathB, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// FileEqual fails through the default handler when pathA and pathB differ in content
+func FileEqual(ctx context.Context, pathA, pathB string, msg string, data ...any) {
+	a := handlerFor(ctx)
+	ok, data := fileEqual(fileSystemOf(a), pathA, pathB, data)
+	a.Assert(ctx, ok, msg, data...)
+}
+
+func fileExists(fsys fs.FS, path string, data []any) (bool, []any) {
+	info, err := fs.Stat(fsys, path)
+	if err != nil {
+		return false, append(data, "path", path, "error", err)
+	}
+	if info.IsDir() {
+		return false, append(fileInfoData(data, "", info, path), "error", "is a directory")
+	}
+	return true, data
+}
+
+func dirExists(fsys fs.FS, path string, data []any) (bool, []any) {
+	info, err := fs.Stat(fsys, path)
+	if err != nil {
+		return false, append(data, "path", path, "error", err)
+	}
+	if !info.IsDir() {
+		return false, append(fileInfoData(data, "", info, path), "error", "not a directory")
+	}
+	return true, data
+}
+
+func fileMode(fsys fs.FS, path string, perm fs.FileMode, data []any) (bool, []any) {
+	info, err := fs.Stat(fsys, path)
+	if err != nil {
+		return false, append(data, "path", path, "error", err)
+	}
+	if info.Mode().Perm() != perm.Perm() {
+		return false, append(fileInfoData(data, "", info, path), "expected_mode", perm.Perm().String())
+	}
+	return true, data
+}
+
+func fileContains(fsys fs.FS, path, substr string, data []any) (bool, []any) {
+	content, info, err := readFile(fsys, path)
+	if err != nil {
+		return false, append(data, "path", path, "error", err)
+	}
+	if !strings.Contains(string(content), substr) {
+		return false, append(fileInfoData(data, "", info, path), "substring", substr)
+	}
+	return true, data
+}
+
+func fileEqual(fsys fs.FS, pathA, pathB string, data []any) (bool, []any) {
+	a, infoA, err := readFile(fsys, pathA)
+	if err != nil {
+		return false, append(data, "path_a", pathA, "error", err)
+	}
+	b, infoB, err := readFile(fsys, pathB)
+	if err != nil {
+		return false, append(data, "path_b", pathB, "error", err)
+	}
+	if bytes.Equal(a, b) {
+		return true, data
+	}
+	data = fileInfoData(data, "_a", infoA, pathA)
+	data = fileInfoData(data, "_b", infoB, pathB)
+	if utf8.Valid(a) && utf8.Valid(b) {
+		return false, diffData(data, string(a), string(b))
+	}
+	offset := 0
+	for offset < len(a) && offset < len(b) && a[offset] == b[offset] {
+		offset++
+	}
+	return false, append(data, "offset", offset)
+}
+
+// fileInfoData appends the path, size, mode and modification time of a file, with the keys
+// ending in suffix
+func fileInfoData(data []any, suffix string, info fs.FileInfo, path string) []any {
+	return append(data,
+		"path"+suffix, path,
+		"size"+suffix, info.Size(),
+		"mode"+suffix, info.Mode().String(),
+		"mod_time"+suffix, info.ModTime().Format(time.RFC3339),
+	)
+}
+
+func readFile(fsys fs.FS, path string) ([]byte, fs.FileInfo, error) {
+	info, err := fs.Stat(fsys, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := fs.ReadFile(fsys, path)
+	return content, info, err
+}
+
+// osFS is the operating system's file system. Unlike os.DirFS it accepts any path os.Open
+// does, including absolute and relative ones.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
@@ -0,0 +1,126 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileSink is a writer keeping an assertion audit log in a dedicated file. When a write would
+// grow the file beyond its maximum size the file is rotated: path becomes path.1, path.1
+// becomes path.2 and so on, keeping at most maxBackups old files. If the rotation fails, writes
+// go on appending to path and the rotation is retried on the next write.
+type FileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileSink opens or creates the file at path for appending. A maxSizeMB of zero or less
+// disables rotation.
+func NewFileSink(path string, maxSizeMB, maxBackups int) (*FileSink, error) {
+	s := &FileSink{path: path, maxBytes: int64(maxSizeMB) << 20, maxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.file, s.size = f, info.Size()
+	return nil
+}
+
+// Write appends p to the file, rotating first if p would not fit
+func (s *FileSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if s.maxBytes > 0 && s.size > 0 && s.size+int64(len(p)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			if s.file == nil {
+				return 0, err
+			}
+			fmt.Fprintln(os.Stderr, "assert: file sink rotation error:", err)
+		}
+	}
+
+	n, err := s.file.Write(p)
+	s.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the backups and starts a new file. The file at path is reopened even when
+// shifting fails, so writes can go on; the file is nil only if reopening failed too.
+// Callers must hold mu.
+func (s *FileSink) rotate() error {
+	err := s.file.Close()
+	s.file = nil
+	if err == nil {
+		err = s.shift()
+	}
+	if openErr := s.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	return err
+}
+
+// shift moves the closed file at path and its backups one place up
+func (s *FileSink) shift() error {
+	if s.maxBackups <= 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	os.Remove(s.backup(s.maxBackups))
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backup(i), s.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(s.path, s.backup(1))
+}
+
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+// Sync commits the file contents to stable storage
+func (s *FileSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return os.ErrClosed
+	}
+	return s.file.Sync()
+}
+
+// Close closes the file; later writes fail
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
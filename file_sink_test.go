@@ -0,0 +1,74 @@
+package assert
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "assert.log")
+	sink, err := NewFileSink(path, 1, 2)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	defer sink.Close()
+	sink.maxBytes = 300
+
+	handler := NewAssertHandler(WithWriter(sink), WithExitFunc(func(code int) {}), WithFormatter(&CLIFormatter{NoColor: true}))
+	for _, msg := range []string{"First", "Second", "Third", "Fourth"} {
+		handler.Assert(context.TODO(), false, msg+" "+strings.Repeat("x", 200))
+	}
+
+	read := func(name string) string {
+		contents, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		return string(contents)
+	}
+	if !strings.Contains(read(path), "Fourth") || !strings.Contains(read(path+".1"), "Third") || !strings.Contains(read(path+".2"), "Second") {
+		t.Fatalf("Expected the newest failures in the current file and its backups")
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("Expected at most two backups, got %v", err)
+	}
+
+	sink.Close()
+	if _, err := sink.Write([]byte("late")); err == nil {
+		t.Fatalf("Expected writes after Close to fail")
+	}
+}
+
+func TestFileSinkRotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "assert.log")
+	sink, err := NewFileSink(path, 1, 1)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	defer sink.Close()
+	sink.maxBytes = 10
+
+	// a non-empty directory in place of the backup makes the rotation fail
+	if err := os.MkdirAll(filepath.Join(path+".1", "busy"), 0o700); err != nil {
+		t.Fatalf("Failed to block the backup: %v", err)
+	}
+	for _, line := range []string{"first line\n", "second line\n"} {
+		if _, err := sink.Write([]byte(line)); err != nil {
+			t.Fatalf("Expected writes to go on when rotation fails, got %v", err)
+		}
+	}
+	if contents, _ := os.ReadFile(path); string(contents) != "first line\nsecond line\n" {
+		t.Fatalf("Expected both lines appended to the current file, got %q", contents)
+	}
+
+	os.RemoveAll(path + ".1")
+	if _, err := sink.Write([]byte("third line\n")); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if contents, _ := os.ReadFile(path + ".1"); string(contents) != "first line\nsecond line\n" {
+		t.Fatalf("Expected the rotation to be retried once the backup is free, got %q", contents)
+	}
+}
@@ -0,0 +1,102 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFileAssertions(t *testing.T) {
+	var buffer bytes.Buffer
+	fsys := fstest.MapFS{
+		"etc/app.yaml":  {Data: []byte("port: 8080\nhost: local\n"), Mode: 0o600, ModTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+		"etc/copy.yaml": {Data: []byte("port: 8080\nhost: local\n")},
+		"etc/prod.yaml": {Data: []byte("port: 443\nhost: local\n")},
+		"bin/a":         {Data: []byte{0xff, 0x00, 0x01}},
+		"bin/b":         {Data: []byte{0xff, 0x00, 0x02}},
+	}
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFileSystem(fsys))
+	ctx := context.TODO()
+
+	handler.FileExists(ctx, "etc/app.yaml", "Config Missing")
+	handler.DirExists(ctx, "etc", "Config Dir Missing")
+	handler.FileMode(ctx, "etc/app.yaml", 0o600, "Config Mode")
+	handler.FileContains(ctx, "etc/app.yaml", "port: 8080", "Config Port")
+	handler.FileEqual(ctx, "etc/app.yaml", "etc/copy.yaml", "Config Copy")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	handler.FileExists(ctx, "etc/missing.yaml", "Config Missing")
+	if !strings.Contains(buffer.String(), "file does not exist") {
+		t.Fatalf("Expected a missing file to fail, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.FileExists(ctx, "etc", "Config Missing")
+	if !strings.Contains(buffer.String(), "is a directory") {
+		t.Fatalf("Expected a directory to fail FileExists, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.DirExists(ctx, "etc/app.yaml", "Config Dir Missing")
+	if !strings.Contains(buffer.String(), "not a directory") || !strings.Contains(buffer.String(), "size=23") {
+		t.Fatalf("Expected a file to fail DirExists with its size, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.FileMode(ctx, "etc/app.yaml", 0o644, "Config Mode")
+	for _, want := range []string{"mode=-rw-------", "expected_mode=-rw-r--r--", "mod_time=2024-05-01T12:00:00Z"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the failure, got %q", want, buffer.String())
+		}
+	}
+
+	buffer.Reset()
+	handler.FileContains(ctx, "etc/app.yaml", "port: 443", "Config Port")
+	if !strings.Contains(buffer.String(), "substring=port: 443") {
+		t.Fatalf("Expected the missing substring to be reported, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.FileEqual(ctx, "etc/app.yaml", "etc/prod.yaml", "Config Copy")
+	for _, want := range []string{"path_a=etc/app.yaml", "size_b=22", "-port: 8080", "+port: 443"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the failure, got %q", want, buffer.String())
+		}
+	}
+
+	buffer.Reset()
+	handler.FileEqual(ctx, "bin/a", "bin/b", "Binary Copy")
+	if !strings.Contains(buffer.String(), "offset=2") {
+		t.Fatalf("Expected the first differing byte of binary files, got %q", buffer.String())
+	}
+}
+
+func TestFileAssertionsUseOperatingSystemByDefault(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+
+	path := filepath.Join(t.TempDir(), "app.conf")
+	if err := os.WriteFile(path, []byte("enabled=true\n"), 0o640); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	FileExists(ctx, path, "Config Missing")
+	FileMode(ctx, path, 0o640, "Config Mode")
+	FileContains(ctx, path, "enabled=true", "Config Enabled")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	DirExists(ctx, path, "Config Dir Missing")
+	if !strings.Contains(buffer.String(), "not a directory") {
+		t.Fatalf("Expected a file to fail DirExists, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,33 @@
+package assert
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// fingerprintKey holds the grouping key of a failure in its data
+const fingerprintKey = "fingerprint"
+
+// fingerprint returns a stable key grouping the occurrences of a failure across formats and
+// sinks: the hash of its code when it has one, otherwise of its message template and the
+// function that reported it. Line numbers are left out so edits elsewhere in a file keep the groups.
+func fingerprint(msg string, data map[string]interface{}) string {
+	h := fnv.New64a()
+	if code, ok := data["code"]; ok {
+		fmt.Fprintf(h, "code\x00%v", code)
+	} else {
+		function := "unknown"
+		if frame, ok := callSite(); ok {
+			function = frame.Function
+		}
+		fmt.Fprintf(h, "msg\x00%s\x00%s", msg, function)
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// addFingerprint sets the fingerprint of a failure unless the caller provided one
+func addFingerprint(msg string, data map[string]interface{}) {
+	if _, ok := data[fingerprintKey]; !ok {
+		data[fingerprintKey] = fingerprint(msg, data)
+	}
+}
@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	handler := NewAssertHandler(WithExitFunc(func(code int) {}), WithDeferMode(true))
+	ctx := context.TODO()
+
+	handler.Assert(ctx, false, "Grouped", "attempt", 1)
+	handler.Assert(ctx, false, "Grouped", "attempt", 2)
+	handler.Assert(ctx, false, "Other")
+	handler.Assert(ctx, false, "Coded", "code", "C1")
+	reportCoded(handler)
+	handler.Assert(ctx, false, "Custom", "fingerprint", "mine")
+
+	events := handler.DeferredEvents()
+	handler.ClearDeferred()
+	if events[0].Fingerprint == "" || events[0].Fingerprint != events[1].Fingerprint {
+		t.Fatalf("Expected one fingerprint for one failure across lines and values, got %q %q", events[0].Fingerprint, events[1].Fingerprint)
+	}
+	if events[2].Fingerprint == events[0].Fingerprint {
+		t.Fatalf("Expected another message to get another fingerprint")
+	}
+	if events[3].Fingerprint != events[4].Fingerprint {
+		t.Fatalf("Expected failures with one code to share a fingerprint across call sites")
+	}
+	if events[5].Fingerprint != "mine" || events[0].Data["fingerprint"] != events[0].Fingerprint {
+		t.Fatalf("Expected the fingerprint in the data and caller-provided fingerprints to be kept")
+	}
+
+	out, _ := (&JSONFormatter{Flatten: true}).FormatEvent(events[0])
+	payload, _ := encodeWebhookPayload(events[0])
+	var formatted, posted struct{ Fingerprint string }
+	json.Unmarshal(out, &formatted)
+	json.Unmarshal(payload, &posted)
+	if formatted.Fingerprint != events[0].Fingerprint || posted.Fingerprint != events[0].Fingerprint {
+		t.Fatalf("Expected the fingerprint in every format, got %s and %s", out, payload)
+	}
+}
+
+func reportCoded(handler *AssertHandler) {
+	handler.Assert(context.TODO(), false, "Coded Elsewhere", "code", "C1")
+}
@@ -0,0 +1,48 @@
+package assert
+
+import "context"
+
+// flagger is implemented by Asserters that can look up feature flags
+type flagger interface {
+	FlagEnabled(name string) bool
+}
+
+// WithFlagProvider sets the feature-flag lookup used by AssertIfFlag, typically a thin
+// wrapper around the organization's flag client
+func WithFlagProvider(provider func(name string) bool) Option {
+	return func(a *AssertHandler) {
+		a.flagProvider = provider
+	}
+}
+
+// FlagEnabled reports whether the named feature flag is on. Without a flag provider every
+// flag is off. Use it to skip computing the condition of an expensive invariant:
+//
+//	if h.FlagEnabled("strict-billing-checks") {
+//		h.Assert(ctx, ledger.Balanced(), "ledger unbalanced")
+//	}
+func (a *AssertHandler) FlagEnabled(name string) bool {
+	a.flushLock.Lock()
+	provider := a.flagProvider
+	a.flushLock.Unlock()
+	return provider != nil && provider(name)
+}
+
+// AssertIfFlag fails when cond is false, but only while the named feature flag is on, so
+// new or expensive invariants can be toggled at runtime
+func (a *AssertHandler) AssertIfFlag(ctx context.Context, flag string, cond bool, msg string, data ...any) {
+	if !cond && !a.FlagEnabled(flag) {
+		return
+	}
+	a.evaluate(ctx, KindAssert, cond, msg, append(data, "flag", flag))
+}
+
+// AssertIfFlag checks cond through the default handler while the named flag is on. Asserters
+// that cannot look up flags treat every flag as off.
+func AssertIfFlag(ctx context.Context, flag string, cond bool, msg string, data ...any) {
+	a := handlerFor(ctx)
+	if f, ok := a.(flagger); !ok || cond || !f.FlagEnabled(flag) {
+		return
+	}
+	a.Assert(ctx, false, msg, append(data, "flag", flag)...)
+}
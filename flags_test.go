@@ -0,0 +1,36 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAssertIfFlag(t *testing.T) {
+	var buffer bytes.Buffer
+	flags := map[string]bool{"strict-billing-checks": true}
+	handler := NewIsolatedHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}),
+		WithFlagProvider(func(name string) bool { return flags[name] }))
+
+	handler.AssertIfFlag(context.TODO(), "strict-billing-checks", true, "Passing Check")
+	handler.AssertIfFlag(context.TODO(), "other-checks", false, "Flag Off")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no output for passing checks or disabled flags, got %q", buffer.String())
+	}
+
+	handler.AssertIfFlag(context.TODO(), "strict-billing-checks", false, "Ledger Unbalanced")
+	if !strings.Contains(buffer.String(), "flag=strict-billing-checks") {
+		t.Fatalf("Expected the flag in the failure, got %q", buffer.String())
+	}
+
+	// package-level calls see flags through decorators
+	buffer.Reset()
+	ctx := NewContext(context.TODO(), WrapHandler(handler, WithPrefix("billing: ")))
+	AssertIfFlag(ctx, "strict-billing-checks", false, "Ledger Unbalanced")
+	flags["strict-billing-checks"] = false
+	AssertIfFlag(ctx, "strict-billing-checks", false, "Toggled Off")
+	if !strings.Contains(buffer.String(), "msg=billing: Ledger Unbalanced") || strings.Contains(buffer.String(), "Toggled Off") {
+		t.Fatalf("Expected only the flagged failure, got %q", buffer.String())
+	}
+}
@@ -3,6 +3,8 @@ package assert
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -12,38 +14,174 @@ type Formatter interface {
 	Format(assertData map[string]interface{}, stack string) string
 }
 
-// TextFormatter is the default plain text output format
-type TextFormatter struct{}
+// EventFormatter renders the typed event and reports rendering errors instead of producing
+// empty output. Handlers use FormatEvent when a formatter implements it, so a formatter can
+// support both interfaces; formatters implementing only EventFormatter are installed with
+// WithEventFormatter. A failed FormatEvent falls back to the TextFormatter output.
+type EventFormatter interface {
+	FormatEvent(event AssertionEvent) ([]byte, error)
+}
+
+// legacyFormatter adapts a Formatter to EventFormatter
+type legacyFormatter struct {
+	Formatter
+}
+
+func (f legacyFormatter) FormatEvent(event AssertionEvent) ([]byte, error) {
+	return []byte(f.Format(event.Data, event.Stack)), nil
+}
+
+func asEventFormatter(f Formatter) EventFormatter {
+	if ef, ok := f.(EventFormatter); ok {
+		return ef
+	}
+	return legacyFormatter{f}
+}
+
+// TextFormatter is the default plain text output format. Keys are written msg first, then
+// sorted, so the output is stable for golden files and diffing.
+type TextFormatter struct {
+	// KeyOrder lists keys to write first, in this order, instead of msg
+	KeyOrder []string
+}
 
 func (f *TextFormatter) Format(assertData map[string]interface{}, stack string) string {
 	output := "ASSERT\n"
-	for key, value := range assertData {
-		output += fmt.Sprintf("   %s=%v\n", key, value)
+	for _, key := range orderedKeys(assertData, f.KeyOrder) {
+		output += fmt.Sprintf("   %s=%s\n", key, renderValue(assertData[key]))
 	}
 	output += fmt.Sprintf("%s\n", stack)
 	return output
 }
 
-// JSONFormatter for JSON output
-type JSONFormatter struct{}
+// JSONFormatter for JSON output. The zero value renders indented JSON with the data nested
+// under "assertData"; the fields adapt the shape to what a log shipper expects. Keys are
+// written msg first, then sorted, with the stack last.
+type JSONFormatter struct {
+	// KeyOrder lists keys to write first, in this order, instead of msg
+	KeyOrder []string
+	// Compact renders the event on a single line so shippers don't split it into several events
+	Compact bool
+	// Flatten puts the data fields at the top level next to "stack" instead of under "assertData"
+	Flatten bool
+	// TimestampKey adds the formatting time under this key when set
+	TimestampKey string
+	// TimeLayout is the layout of the timestamp, time.RFC3339 if empty
+	TimeLayout string
+}
 
 func (f *JSONFormatter) Format(assertData map[string]interface{}, stack string) string {
-	data := map[string]interface{}{
-		"assertData": assertData,
-		"stack":      stack,
-	}
-	out, _ := json.MarshalIndent(data, "", "  ")
+	out, _ := f.format(assertData, stack, time.Now())
 	return string(out)
 }
 
-// YAMLFormatter for YAML output
-type YAMLFormatter struct{}
+// FormatEvent renders the event like Format, using the event time for the timestamp
+func (f *JSONFormatter) FormatEvent(event AssertionEvent) ([]byte, error) {
+	return f.format(event.Data, event.Stack, event.Time)
+}
 
-func (f *YAMLFormatter) Format(assertData map[string]interface{}, stack string) string {
-	data := map[string]interface{}{
-		"assertData": assertData,
-		"stack":      stack,
+func (f *JSONFormatter) format(assertData map[string]interface{}, stack string, now time.Time) ([]byte, error) {
+	assertData = marshalableData(assertData, json.Marshal)
+
+	data := map[string]interface{}{}
+	var keys []string
+	if f.Flatten {
+		for k, v := range assertData {
+			data[k] = v
+		}
+	} else {
+		data["assertData"] = orderedJSON{keys: orderedKeys(assertData, f.KeyOrder), values: assertData}
+		keys = []string{"assertData"}
+	}
+
+	if f.TimestampKey != "" {
+		layout := f.TimeLayout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		data[f.TimestampKey] = now.Format(layout)
+		if !f.Flatten {
+			keys = append([]string{f.TimestampKey}, keys...)
+		}
+	}
+	if f.Flatten {
+		keys = orderedKeys(data, f.KeyOrder)
+	}
+
+	// the stack always comes last
+	if _, ok := data["stack"]; !ok {
+		keys = append(keys, "stack")
+	}
+	data["stack"] = stack
+	ordered := orderedJSON{keys: keys, values: data}
+
+	if f.Compact {
+		return json.Marshal(ordered)
 	}
-	out, _ := yaml.Marshal(data)
+	return json.MarshalIndent(ordered, "", "  ")
+}
+
+// YAMLFormatter for YAML output. Keys are written msg first, then sorted, with the stack last.
+type YAMLFormatter struct {
+	// KeyOrder lists keys to write first, in this order, instead of msg
+	KeyOrder []string
+}
+
+func (f *YAMLFormatter) Format(assertData map[string]interface{}, stack string) string {
+	out, _ := f.FormatEvent(AssertionEvent{Data: assertData, Stack: stack})
 	return string(out)
 }
+
+func (f *YAMLFormatter) FormatEvent(event AssertionEvent) ([]byte, error) {
+	data := yaml.MapSlice{
+		{Key: "assertData", Value: orderedYAML(marshalableData(event.Data, yamlMarshal), f.KeyOrder)},
+		{Key: "stack", Value: event.Stack},
+	}
+	return yamlMarshal(data)
+}
+
+// yamlMarshal turns the panics yaml.Marshal raises for unsupported types into errors
+func yamlMarshal(v interface{}) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("yaml: %v", r)
+		}
+	}()
+	return yaml.Marshal(v)
+}
+
+// marshalFallbackKey lists the keys whose values could not be marshaled and were replaced
+// with their %#v rendering
+const marshalFallbackKey = "marshal_fallback"
+
+// marshalableData returns assertData unchanged when marshal accepts it. Otherwise it returns
+// a copy where every value marshal rejects, such as channels, funcs or cycles, is replaced
+// by its %#v rendering (or prettyPrint for cycles) and the replaced keys are listed under
+// marshal_fallback.
+func marshalableData(assertData map[string]interface{}, marshal func(interface{}) ([]byte, error)) map[string]interface{} {
+	// cycles can overflow the stack of some encoders before they report an error
+	if !hasCycle(assertData) {
+		if _, err := marshal(assertData); err == nil {
+			return assertData
+		}
+	}
+
+	data := make(map[string]interface{}, len(assertData)+1)
+	var fallback []string
+	for k, v := range assertData {
+		if hasCycle(v) {
+			data[k] = prettyPrint(v)
+			fallback = append(fallback, k)
+			continue
+		}
+		if _, err := marshal(v); err != nil {
+			data[k] = fmt.Sprintf("%#v", v)
+			fallback = append(fallback, k)
+			continue
+		}
+		data[k] = v
+	}
+	sort.Strings(fallback)
+	data[marshalFallbackKey] = fallback
+	return data
+}
@@ -0,0 +1,127 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatterOptions(t *testing.T) {
+	data := map[string]interface{}{"msg": "Test Failure", "shard": 3}
+
+	compact := (&JSONFormatter{Compact: true, Flatten: true, TimestampKey: "@timestamp"}).Format(data, "stack trace")
+	if strings.Contains(compact, "\n") {
+		t.Fatalf("Expected single-line output, got %q", compact)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(compact), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON: %v", err)
+	}
+	if decoded["msg"] != "Test Failure" || decoded["shard"] != float64(3) || decoded["stack"] != "stack trace" {
+		t.Fatalf("Expected flattened fields, got %v", decoded)
+	}
+	if _, err := time.Parse(time.RFC3339, decoded["@timestamp"].(string)); err != nil {
+		t.Fatalf("Expected an RFC3339 timestamp: %v", err)
+	}
+
+	indented := (&JSONFormatter{}).Format(data, "stack trace")
+	if !strings.Contains(indented, "\n  \"assertData\": {") {
+		t.Fatalf("Expected the zero value to keep the indented nested shape, got %s", indented)
+	}
+}
+
+func TestMarshalFallback(t *testing.T) {
+	data := map[string]interface{}{"msg": "Bad Value", "events": make(chan int), "callback": func() {}}
+
+	for name, formatter := range map[string]Formatter{"json": &JSONFormatter{Compact: true}, "yaml": &YAMLFormatter{}} {
+		out := formatter.Format(data, "stack trace")
+		for _, expected := range []string{"Bad Value", "(chan int)", "(func())", "callback", "marshal_fallback"} {
+			if !strings.Contains(out, expected) {
+				t.Fatalf("%s: expected %q in output, got %q", name, expected, out)
+			}
+		}
+	}
+	if _, ok := data[marshalFallbackKey]; ok {
+		t.Fatalf("Expected the event data to be left untouched")
+	}
+}
+
+// lineFormatter implements only the event formatter interface
+type lineFormatter struct {
+	err error
+}
+
+func (f lineFormatter) FormatEvent(event AssertionEvent) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte(event.Severity.String() + " " + event.Message + " at " + event.Caller), nil
+}
+
+func TestEventFormatter(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithEventFormatter(lineFormatter{}))
+
+	handler.Assert(context.TODO(), false, "Typed Event")
+	if !strings.Contains(buffer.String(), "ERROR Typed Event at ") || !strings.Contains(buffer.String(), "formatter_test.go:") {
+		t.Fatalf("Expected the typed event to be formatted, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler = NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithEventFormatter(lineFormatter{err: errors.New("boom")}))
+	handler.Assert(context.TODO(), false, "Broken Formatter")
+	if !strings.Contains(buffer.String(), "Formatter error: boom") || !strings.Contains(buffer.String(), "msg=Broken Formatter") {
+		t.Fatalf("Expected a reported error and the text fallback, got %q", buffer.String())
+	}
+
+	var frames []AssertionEvent
+	handler = NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithExporter(exporterFunc(func(events []AssertionEvent) {
+		frames = append(frames, events...)
+	})))
+	handler.Assert(context.TODO(), false, "Frames")
+	if len(frames) != 1 || len(frames[0].Frames) == 0 || frames[0].Frames[0].Function != "github.com/ZanzyTHEbar/assert-lib.TestEventFormatter" {
+		t.Fatalf("Expected frames starting at the caller, got %+v", frames)
+	}
+}
+
+// exporterFunc collects exported events in tests
+type exporterFunc func(events []AssertionEvent)
+
+func (f exporterFunc) Export(ctx context.Context, events []AssertionEvent) error {
+	f(events)
+	return nil
+}
+
+func (f exporterFunc) Shutdown(ctx context.Context) error { return nil }
+
+func TestDeterministicKeyOrder(t *testing.T) {
+	data := map[string]interface{}{"zeta": 1, "msg": "Ordered", "alpha": 2, "code": "E1"}
+
+	text := (&TextFormatter{}).Format(data, "stack trace")
+	if text != "ASSERT\n   msg=Ordered\n   alpha=2\n   code=E1\n   zeta=1\nstack trace\n" {
+		t.Fatalf("Unexpected text order: %q", text)
+	}
+	custom := (&TextFormatter{KeyOrder: []string{"code", "msg"}}).Format(data, "")
+	if !strings.HasPrefix(custom, "ASSERT\n   code=E1\n   msg=Ordered\n   alpha=2\n") {
+		t.Fatalf("Unexpected custom order: %q", custom)
+	}
+
+	compact := (&JSONFormatter{Compact: true}).Format(data, "stack trace")
+	if compact != `{"assertData":{"msg":"Ordered","alpha":2,"code":"E1","zeta":1},"stack":"stack trace"}` {
+		t.Fatalf("Unexpected JSON order: %s", compact)
+	}
+	flat := (&JSONFormatter{Compact: true, Flatten: true}).Format(data, "stack trace")
+	if flat != `{"msg":"Ordered","alpha":2,"code":"E1","zeta":1,"stack":"stack trace"}` {
+		t.Fatalf("Unexpected flattened JSON order: %s", flat)
+	}
+
+	yamlOut := (&YAMLFormatter{}).Format(data, "stack trace")
+	if yamlOut != "assertData:\n  msg: Ordered\n  alpha: 2\n  code: E1\n  zeta: 1\nstack: stack trace\n" {
+		t.Fatalf("Unexpected YAML order: %q", yamlOut)
+	}
+}
@@ -0,0 +1,114 @@
+// Package formattertest provides a conformance suite for assert.Formatter implementations,
+// so custom formatters can be checked against the expectations of the built-in ones.
+package formattertest
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// hugeValueSize is the size of the value used by the huge value check
+const hugeValueSize = 1 << 20
+
+// Run checks that f tolerates an empty stack, empty and nil data, huge values and invalid
+// UTF-8, is safe for concurrent use, renders the message, is deterministic and leaves its
+// input untouched. Formatters also implementing assert.EventFormatter must not fail on any
+// of these inputs.
+func Run(t *testing.T, f assert.Formatter) {
+	t.Helper()
+
+	t.Run("EmptyStack", func(t *testing.T) {
+		out := format(t, f, sample(), "")
+		if !strings.Contains(out, "formattertest message") {
+			t.Errorf("expected the message in the output, got %q", out)
+		}
+	})
+
+	t.Run("EmptyData", func(t *testing.T) {
+		format(t, f, map[string]interface{}{}, "stack")
+		format(t, f, nil, "stack")
+	})
+
+	t.Run("HugeValue", func(t *testing.T) {
+		data := sample()
+		data["payload"] = strings.Repeat("x", hugeValueSize)
+		if out := format(t, f, data, "stack"); !strings.Contains(out, "formattertest message") {
+			t.Errorf("expected the message next to a huge value, got %d bytes without it", len(out))
+		}
+	})
+
+	t.Run("InvalidUTF8", func(t *testing.T) {
+		data := sample()
+		data["raw"] = "\xff\xfe\xfd"
+		data["\xc3\x28"] = "invalid key"
+		if out := format(t, f, data, "stack \xff"); out == "" {
+			t.Errorf("expected output for invalid UTF-8 input")
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		data := sample()
+		for i := 0; i < 20; i++ {
+			data[string(rune('a'+i))] = i
+		}
+		if first, second := format(t, f, data, "stack"), format(t, f, data, "stack"); first != second {
+			t.Errorf("expected identical output for identical input:\n%s\n%s", first, second)
+		}
+	})
+
+	t.Run("InputUntouched", func(t *testing.T) {
+		data := sample()
+		format(t, f, data, "stack")
+		if !reflect.DeepEqual(data, sample()) {
+			t.Errorf("expected the formatter not to modify its input, got %v", data)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		data := sample()
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					f.Format(data, "stack")
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func sample() map[string]interface{} {
+	return map[string]interface{}{
+		"msg":      "formattertest message",
+		"area":     "Assert",
+		"severity": "ERROR",
+		"caller":   "main.go:42",
+		"count":    3,
+		"nested":   map[string]interface{}{"key": "value"},
+	}
+}
+
+// format runs Format, and FormatEvent when implemented, failing the test on panics or errors
+func format(t *testing.T, f assert.Formatter, data map[string]interface{}, stack string) (out string) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("formatter panicked: %v", r)
+		}
+	}()
+
+	out = f.Format(data, stack)
+	if ef, ok := f.(assert.EventFormatter); ok {
+		if _, err := ef.FormatEvent(assert.AssertionEvent{Message: "formattertest message", Data: data, Stack: stack}); err != nil {
+			t.Errorf("FormatEvent failed: %v", err)
+		}
+	}
+	return out
+}
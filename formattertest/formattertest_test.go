@@ -0,0 +1,30 @@
+package formattertest
+
+import (
+	"testing"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+func TestBuiltinFormatters(t *testing.T) {
+	templateFormatter, err := assert.NewTemplateFormatter("{{.Severity}} {{.Msg}}{{range .Fields}} {{.}}={{index $.Data .}}{{end}}")
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	formatters := map[string]assert.Formatter{
+		"Text":        &assert.TextFormatter{},
+		"JSON":        &assert.JSONFormatter{},
+		"CompactJSON": &assert.JSONFormatter{Compact: true, Flatten: true},
+		"YAML":        &assert.YAMLFormatter{},
+		"CLI":         &assert.CLIFormatter{Verbosity: assert.VerbosityVerbose},
+		"ColorText":   &assert.ColorTextFormatter{Color: true},
+		"Template":    templateFormatter,
+		"Fast":        &assert.FastFormatter{},
+	}
+	for name, f := range formatters {
+		t.Run(name, func(t *testing.T) {
+			Run(t, f)
+		})
+	}
+}
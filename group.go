@@ -0,0 +1,110 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AssertGroup collects assertions belonging to one logical unit, such as validating a
+// config object, and tracks how many of them passed and failed
+type AssertGroup struct {
+	name    string
+	handler *AssertHandler
+	owner   *AssertHandler
+
+	mu     sync.Mutex
+	passed int
+	failed int
+}
+
+// Group returns a new group whose failures are reported through a tagged with "group".
+// Summaries of a's groups are written whenever deferred assertions are processed, until the
+// group is ended with End.
+func (a *AssertHandler) Group(name string) *AssertGroup {
+	g := &AssertGroup{
+		name:    name,
+		handler: a.WithTags("group", name),
+		owner:   a,
+	}
+
+	a.flushLock.Lock()
+	a.groups = append(a.groups, g)
+	a.flushLock.Unlock()
+
+	return g
+}
+
+func (g *AssertGroup) record(ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ok {
+		g.passed++
+	} else {
+		g.failed++
+	}
+}
+
+// Assert records the check and fails through the group handler when truth is false
+func (g *AssertGroup) Assert(ctx context.Context, truth bool, msg string, data ...any) {
+	g.record(truth)
+	g.handler.Assert(ctx, truth, msg, data...)
+}
+
+// NotNil records the check and fails through the group handler when item is nil
+func (g *AssertGroup) NotNil(ctx context.Context, item any, msg string, data ...any) {
+	g.record(!isNil(item))
+	g.handler.NotNil(ctx, item, msg, data...)
+}
+
+// NoError records the check and fails through the group handler when err is not nil
+func (g *AssertGroup) NoError(ctx context.Context, err error, msg string, data ...any) {
+	g.record(err == nil)
+	g.handler.NoError(ctx, err, msg, data...)
+}
+
+// Name returns the group label
+func (g *AssertGroup) Name() string {
+	return g.name
+}
+
+// Counts returns how many checks passed and failed so far
+func (g *AssertGroup) Counts() (passed, failed int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.passed, g.failed
+}
+
+// Summary renders the group counts on one line
+func (g *AssertGroup) Summary() string {
+	passed, failed := g.Counts()
+	return fmt.Sprintf("group %s: %d checks, %d passed, %d failed", g.name, passed+failed, passed, failed)
+}
+
+// End unregisters the group from the handler that created it, so processing deferred
+// assertions no longer writes its summary. The group can still be used and flushed.
+func (g *AssertGroup) End() {
+	g.owner.flushLock.Lock()
+	defer g.owner.flushLock.Unlock()
+	for i, other := range g.owner.groups {
+		if other == g {
+			g.owner.groups = append(g.owner.groups[:i], g.owner.groups[i+1:]...)
+			return
+		}
+	}
+}
+
+// writeGroupSummaries writes the summaries of the groups that have not ended. Callers must
+// hold flushLock.
+func (a *AssertHandler) writeGroupSummaries() {
+	for _, g := range a.groups {
+		fmt.Fprintln(a.writer, g.Summary())
+	}
+}
+
+// Flush writes the group summary to the handler writer
+func (g *AssertGroup) Flush() {
+	g.handler.flushLock.Lock()
+	defer g.handler.flushLock.Unlock()
+	fmt.Fprintln(g.handler.writer, g.Summary())
+}
@@ -0,0 +1,56 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAssertGroupSummary(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithDeferMode(true))
+
+	group := handler.Group("config")
+	group.Assert(context.TODO(), true, "Port Set")
+	group.NotNil(context.TODO(), "db", "Database Set")
+	group.NoError(context.TODO(), errors.New("missing"), "TLS Loaded")
+
+	passed, failed := group.Counts()
+	if passed != 2 || failed != 1 {
+		t.Fatalf("Expected 2 passed and 1 failed, got %d and %d", passed, failed)
+	}
+
+	buffer.Reset()
+	handler.ProcessDeferredAssertions(context.TODO())
+
+	if !bytes.Contains(buffer.Bytes(), []byte("group config: 3 checks, 2 passed, 1 failed")) {
+		t.Fatalf("Expected group summary in processed output, got %s", buffer.String())
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("group=config")) {
+		t.Fatalf("Expected group tag on the failure")
+	}
+}
+
+func TestAssertGroupEnd(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithDeferMode(true))
+
+	group := handler.Group("cache")
+	group.Assert(context.TODO(), true, "Warm")
+	handler.ProcessDeferredAssertions(context.TODO())
+	if !bytes.Contains(buffer.Bytes(), []byte("group cache: 1 checks, 1 passed, 0 failed")) {
+		t.Fatalf("Expected the summary of a passing group, got %s", buffer.String())
+	}
+
+	group.End()
+	buffer.Reset()
+	handler.Assert(context.TODO(), false, "Unrelated")
+	handler.ProcessDeferredAssertions(context.TODO())
+	if bytes.Contains(buffer.Bytes(), []byte("group cache")) {
+		t.Fatalf("Expected no summary after End, got %s", buffer.String())
+	}
+	if len(handler.groups) != 0 {
+		t.Fatalf("Expected the group to be unregistered, got %d groups", len(handler.groups))
+	}
+}
@@ -0,0 +1,133 @@
+package assert
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// historyMinutes is how many one-minute buckets History keeps per code
+const historyMinutes = 60
+
+// failureRing counts failures per minute over the last hour
+type failureRing struct {
+	counts  [historyMinutes]int
+	minutes [historyMinutes]int64
+}
+
+func (r *failureRing) add(minute int64) {
+	i := minute % historyMinutes
+	if r.minutes[i] != minute {
+		r.minutes[i] = minute
+		r.counts[i] = 0
+	}
+	r.counts[i]++
+}
+
+func (r *failureRing) window(minute int64) []int {
+	out := make([]int, historyMinutes)
+	for k := 0; k < historyMinutes; k++ {
+		m := minute - int64(historyMinutes-1-k)
+		if i := m % historyMinutes; r.minutes[i] == m {
+			out[k] = r.counts[i]
+		}
+	}
+	return out
+}
+
+// history keeps a failure histogram per code; derived handlers share their parent's
+type history struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	rings map[string]*failureRing
+	// recent holds the last recentEvents failures, oldest first
+	recent []AssertionEvent
+}
+
+// recentEvents is how many of the latest failures are kept for DumpState
+const recentEvents = 20
+
+func newHistory() *history {
+	return &history{now: time.Now, rings: make(map[string]*failureRing)}
+}
+
+func (h *history) record(code string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ring, ok := h.rings[code]
+	if !ok {
+		ring = &failureRing{}
+		h.rings[code] = ring
+	}
+	ring.add(h.now().Unix() / 60)
+}
+
+// remember keeps event among the recent failures
+func (h *history) remember(event AssertionEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.recent) == recentEvents {
+		h.recent = append(h.recent[:0], h.recent[1:]...)
+	}
+	h.recent = append(h.recent, event)
+}
+
+// RecentEvents returns the latest failures reported through this handler family, oldest first
+func (a *AssertHandler) RecentEvents() []AssertionEvent {
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	return append([]AssertionEvent(nil), a.history.recent...)
+}
+
+// History returns the failures per minute reported with the given code over the last hour,
+// oldest minute first, so the current minute is the last element. Failures without a code
+// are counted under "".
+func (a *AssertHandler) History(code string) []int {
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	ring, ok := a.history.rings[code]
+	if !ok {
+		return make([]int, historyMinutes)
+	}
+	return ring.window(a.history.now().Unix() / 60)
+}
+
+// historyCodes returns the codes with a histogram in sorted order
+func (a *AssertHandler) historyCodes() []string {
+	a.history.mu.Lock()
+	defer a.history.mu.Unlock()
+	codes := make([]string, 0, len(a.history.rings))
+	for code := range a.history.rings {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// recordHistory adds a reported failure to the histogram of its code
+func (a *AssertHandler) recordHistory(data map[string]interface{}) {
+	code := ""
+	if c, ok := data["code"]; ok {
+		code = fmt.Sprint(c)
+	}
+	a.history.record(code)
+}
+
+// DebugInfo is the handler's introspection data: the taxonomy, the per-minute failure
+// history of every code and the assertion counters of Stats
+type DebugInfo struct {
+	Taxonomy []TaxonomyEntry  `json:"taxonomy"`
+	History  map[string][]int `json:"history"`
+	Stats    Stats            `json:"stats"`
+}
+
+// DebugInfo returns the handler's introspection data, e.g. for an internal debug endpoint
+// such as the one of the assertdebug package
+func (a *AssertHandler) DebugInfo() DebugInfo {
+	histories := make(map[string][]int)
+	for _, code := range a.historyCodes() {
+		histories[code] = a.History(code)
+	}
+	return DebugInfo{Taxonomy: a.Taxonomy(), History: histories, Stats: a.Stats()}
+}
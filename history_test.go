@@ -0,0 +1,46 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	clock := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+	handler.history.now = func() time.Time { return clock }
+
+	handler.Assert(context.TODO(), false, "Lag High", "code", "LAG")
+	clock = clock.Add(time.Minute)
+	handler.Assert(context.TODO(), false, "Lag High", "code", "LAG")
+	handler.Assert(context.TODO(), false, "Lag High", "code", "LAG")
+
+	h := handler.History("LAG")
+	if len(h) != 60 || h[59] != 2 || h[58] != 1 || h[57] != 0 {
+		t.Fatalf("Unexpected history: %v", h)
+	}
+
+	// buckets older than an hour are recycled
+	clock = clock.Add(59 * time.Minute)
+	handler.Assert(context.TODO(), false, "Lag High", "code", "LAG")
+	h = handler.History("LAG")
+	if h[59] != 1 || h[0] != 2 || sum(h) != 3 {
+		t.Fatalf("Expected the oldest minute to drop out, got %v", h)
+	}
+
+	if info := handler.DebugInfo(); sum(info.History["LAG"]) != 3 {
+		t.Fatalf("Expected the debug info to include the history, got %v", info.History)
+	}
+}
+
+func sum(counts []int) int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
@@ -0,0 +1,23 @@
+package assert
+
+import "context"
+
+// FailureHook is called synchronously with every reported failure, after it was written and
+// exported, before the handler defers, panics or exits. Hooks run with the handler's lock
+// held and must not call back into the handler.
+type FailureHook func(ctx context.Context, event AssertionEvent)
+
+// WithOnFailure registers a hook called for every reported failure, e.g. to forward it to
+// an error tracker
+func WithOnFailure(hook FailureHook) Option {
+	return func(a *AssertHandler) {
+		a.onFailure = append(a.onFailure, hook)
+	}
+}
+
+// runHooks calls the failure hooks. Callers must hold flushLock.
+func (a *AssertHandler) runHooks(ctx context.Context, event AssertionEvent) {
+	for _, hook := range a.onFailure {
+		hook(ctx, event)
+	}
+}
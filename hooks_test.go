@@ -0,0 +1,21 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestOnFailure(t *testing.T) {
+	var buffer bytes.Buffer
+	var seen []AssertionEvent
+	hook := func(ctx context.Context, event AssertionEvent) { seen = append(seen, event) }
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithOnFailure(hook))
+
+	handler.Assert(context.TODO(), true, "Holds")
+	handler.WithTags("subsystem", "cache").Assert(context.TODO(), false, "Broken", "code", "C1")
+
+	if len(seen) != 1 || seen[0].Message != "Broken" || seen[0].Data["subsystem"] != "cache" || len(seen[0].Frames) == 0 {
+		t.Fatalf("Expected the hook to see the failure with its data and frames, got %+v", seen)
+	}
+}
@@ -0,0 +1,145 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithNumberSeparator renders the integer and float data values under keys, and values
+// wrapped in Grouped, with sep between thousands, e.g. 1234567 as "1,234,567". Other numbers
+// such as ports, years and IDs are left as they are.
+func WithNumberSeparator(sep string, keys ...string) Option {
+	return func(a *AssertHandler) {
+		a.numberSeparator = sep
+		a.separatedKeys = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			a.separatedKeys[key] = true
+		}
+	}
+}
+
+// Grouped marks a number to be rendered with the separator of WithNumberSeparator whatever
+// its key. Without a separator it renders as the plain number.
+type Grouped struct {
+	Value any
+}
+
+func (g Grouped) String() string {
+	return fmt.Sprint(g.Value)
+}
+
+func (g Grouped) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Value)
+}
+
+// WithHumanDurations renders time.Duration data values rounded to a readable precision,
+// e.g. "1.23s" or "3m45s", instead of nanosecond integers or full-precision strings
+func WithHumanDurations() Option {
+	return func(a *AssertHandler) {
+		a.humanDurations = true
+	}
+}
+
+// WithTimeLayout renders time.Time data values with layout, e.g. time.RFC3339
+func WithTimeLayout(layout string) Option {
+	return func(a *AssertHandler) {
+		a.timeLayout = layout
+	}
+}
+
+// humanizeValues applies the configured number, duration and time rendering to data so
+// every formatter shows the same representation
+func (a *AssertHandler) humanizeValues(data map[string]interface{}) {
+	if a.numberSeparator == "" && !a.humanDurations && a.timeLayout == "" {
+		return
+	}
+
+	for k, v := range data {
+		switch value := v.(type) {
+		case time.Duration:
+			if a.humanDurations {
+				data[k] = humanDuration(value)
+			}
+		case time.Time:
+			if a.timeLayout != "" {
+				data[k] = value.Format(a.timeLayout)
+			}
+		case Grouped:
+			if a.numberSeparator != "" {
+				if s, ok := separateNumber(value.Value, a.numberSeparator); ok {
+					data[k] = s
+				}
+			}
+		default:
+			if a.separatedKeys[k] {
+				if s, ok := separateNumber(v, a.numberSeparator); ok {
+					data[k] = s
+				}
+			}
+		}
+	}
+}
+
+func humanDuration(d time.Duration) string {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs >= time.Minute:
+		return d.Round(time.Second).String()
+	case abs >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case abs >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.String()
+	}
+}
+
+// separateNumber formats integer and float values with sep between groups of thousands
+func separateNumber(v any, sep string) (string, bool) {
+	rv := reflect.ValueOf(v)
+	var s string
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		s = strconv.FormatFloat(rv.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		s = strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	default:
+		return "", false
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	if len(intPart) <= 3 {
+		return sign + s, true
+	}
+
+	var b strings.Builder
+	head := len(intPart) % 3
+	if head > 0 {
+		b.WriteString(intPart[:head])
+	}
+	for i := head; i < len(intPart); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(intPart[i : i+3])
+	}
+	return sign + b.String() + frac, true
+}
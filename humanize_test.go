@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHumanizedValues(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) {}),
+		WithFormatter(&JSONFormatter{}),
+		WithNumberSeparator(",", "rows", "negative", "small", "ratio"),
+		WithHumanDurations(),
+		WithTimeLayout(time.RFC3339),
+	)
+
+	handler.Assert(context.TODO(), false, "Quantities",
+		"rows", 1234567,
+		"negative", -12345.5,
+		"small", 42,
+		"ratio", float32(0.1),
+		"port", 8080,
+		"bytes", Grouped{int64(9876543)},
+		"latency", 1234567891*time.Nanosecond,
+		"uptime", 3*time.Minute+45*time.Second+300*time.Millisecond,
+		"at", time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC),
+	)
+
+	for _, want := range []string{
+		`"rows": "1,234,567"`,
+		`"negative": "-12,345.5"`,
+		`"small": "42"`,
+		`"ratio": "0.1"`,
+		`"port": 8080`,
+		`"bytes": "9,876,543"`,
+		`"latency": "1.23s"`,
+		`"uptime": "3m45s"`,
+		`"at": "2024-10-16T12:00:00Z"`,
+	} {
+		if !bytes.Contains(buffer.Bytes(), []byte(want)) {
+			t.Fatalf("Expected %s in output, got %s", want, buffer.String())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package assert
+
+import "fmt"
+
+// exitPanic is the sentinel panicked with instead of exiting when WithExitPanic is set
+type exitPanic struct {
+	err *AssertionError
+}
+
+// WithExitPanic makes fatal failures panic with a sentinel recovered by RunIsolated instead of
+// calling the exit function, so fatal paths can be exercised in tests
+func WithExitPanic() Option {
+	return func(a *AssertHandler) {
+		a.exitPanic = true
+	}
+}
+
+// RunIsolated runs fn and returns the failure that would have exited the process, if any.
+// Handlers used inside fn must be configured with WithExitPanic; other panics are re-raised.
+func RunIsolated(fn func()) (err *AssertionError) {
+	defer func() {
+		if r := recover(); r != nil {
+			p, ok := r.(exitPanic)
+			if !ok {
+				panic(r)
+			}
+			err = p.err
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+func (p exitPanic) String() string {
+	return fmt.Sprintf("assert: unrecovered fatal assertion (use RunIsolated): %s", p.err.Msg)
+}
@@ -0,0 +1,41 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestRunIsolatedRecoversFatal(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitPanic(), WithExitFunc(func(code int) {
+		t.Fatalf("Expected exit to be replaced by a panic")
+	}))
+
+	reachedEnd := false
+	err := RunIsolated(func() {
+		handler.Assert(context.TODO(), false, "Isolated Fatal", "id", 7)
+		reachedEnd = true
+	})
+
+	if err == nil || err.Msg != "Isolated Fatal" || err.Data["id"] != 7 {
+		t.Fatalf("Expected isolated assertion error, got %+v", err)
+	}
+	if reachedEnd {
+		t.Fatalf("Expected fatal assertion not to return")
+	}
+
+	if err := RunIsolated(func() {}); err != nil {
+		t.Fatalf("Expected no error when nothing fails, got %v", err)
+	}
+}
+
+func TestRunIsolatedRepanicsOtherValues(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("Expected unrelated panic to propagate, got %v", r)
+		}
+	}()
+
+	RunIsolated(func() { panic("boom") })
+}
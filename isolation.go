@@ -0,0 +1,34 @@
+package assert
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithNoGlobalState guarantees the handler never touches process-global state of this
+// package or the standard library: it cannot be installed as the default handler and does
+// not log through slog.Default. Handlers derived from it inherit the guarantee.
+func WithNoGlobalState() Option {
+	return func(a *AssertHandler) {
+		a.isolated = true
+	}
+}
+
+// NewIsolatedHandler returns a handler meant for libraries embedding assert-lib, so they
+// never mutate or depend on the default handler configured by the application
+func NewIsolatedHandler(opts ...Option) *AssertHandler {
+	return NewAssertHandler(append([]Option{WithNoGlobalState()}, opts...)...)
+}
+
+// Isolated reports whether the handler was created with WithNoGlobalState
+func (a *AssertHandler) Isolated() bool {
+	return a.isolated
+}
+
+// logError logs through slog.Default unless the handler is isolated
+func (a *AssertHandler) logError(ctx context.Context, msg string) {
+	if a.isolated {
+		return
+	}
+	slog.ErrorContext(ctx, msg)
+}
@@ -0,0 +1,34 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestIsolatedHandler(t *testing.T) {
+	defer SetDefaultHandler(nil)
+	SetDefaultHandler(nil)
+
+	var libBuffer bytes.Buffer
+	lib := NewIsolatedHandler(WithWriter(&libBuffer), WithExitFunc(func(code int) {}))
+
+	var appBuffer bytes.Buffer
+	Configure(WithWriter(&appBuffer), WithFormatter(&JSONFormatter{}))
+
+	lib.Assert(context.TODO(), false, "Library Failure")
+
+	if appBuffer.Len() != 0 || !bytes.Contains(libBuffer.Bytes(), []byte("msg=Library Failure")) {
+		t.Fatalf("Expected the isolated handler to ignore global configuration")
+	}
+	if !lib.WithTags("k", "v").Isolated() {
+		t.Fatalf("Expected derived handlers to stay isolated")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected installing an isolated handler as default to panic")
+		}
+	}()
+	SetDefaultHandler(lib)
+}
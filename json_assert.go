@@ -0,0 +1,220 @@
+package assert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONEq fails when expected and actual are not the same JSON document, ignoring key order
+// and whitespace. Differences are reported as a diff of the normalized documents.
+func (a *AssertHandler) JSONEq(ctx context.Context, expected, actual string, msg string, data ...any) {
+	ok, data := jsonEq(expected, actual, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// JSONEq fails through the default handler when expected and actual are not the same JSON document
+func JSONEq(ctx context.Context, expected, actual string, msg string, data ...any) {
+	ok, data := jsonEq(expected, actual, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// JSONContains fails when doc does not contain subset: every key of a subset object must be
+// in the document object with a containing value, and every element of a subset array must
+// be contained in some element of the document array
+func (a *AssertHandler) JSONContains(ctx context.Context, doc, subset string, msg string, data ...any) {
+	ok, data := jsonContains(doc, subset, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// JSONContains fails through the default handler when doc does not contain subset
+func JSONContains(ctx context.Context, doc, subset string, msg string, data ...any) {
+	ok, data := jsonContains(doc, subset, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// JSONPath fails when the value at path in doc is not expected, compared as JSON. Paths are
+// written like $.items[0].name or items.0.name; keys containing dots can be quoted as ["a.b"].
+func (a *AssertHandler) JSONPath(ctx context.Context, doc, path string, expected any, msg string, data ...any) {
+	ok, data := jsonPath(doc, path, expected, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// JSONPath fails through the default handler when the value at path in doc is not expected
+func JSONPath(ctx context.Context, doc, path string, expected any, msg string, data ...any) {
+	ok, data := jsonPath(doc, path, expected, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+func jsonEq(expected, actual string, data []any) (bool, []any) {
+	ev, err := decodeJSON("expected", expected)
+	if err != nil {
+		return false, append(data, "error", err)
+	}
+	av, err := decodeJSON("actual", actual)
+	if err != nil {
+		return false, append(data, "error", err)
+	}
+	if reflect.DeepEqual(ev, av) {
+		return true, data
+	}
+	// indented encoding sorts the keys, making the documents comparable line by line
+	e, _ := json.MarshalIndent(ev, "", "  ")
+	act, _ := json.MarshalIndent(av, "", "  ")
+	return false, diffData(data, string(e), string(act))
+}
+
+func jsonContains(doc, subset string, data []any) (bool, []any) {
+	dv, err := decodeJSON("doc", doc)
+	if err != nil {
+		return false, append(data, "error", err)
+	}
+	sv, err := decodeJSON("subset", subset)
+	if err != nil {
+		return false, append(data, "error", err)
+	}
+	if path, ok := jsonContainsAt("$", dv, sv); !ok {
+		return false, append(data, "mismatch", path)
+	}
+	return true, data
+}
+
+// jsonContainsAt reports whether doc contains subset, and otherwise the path of the first mismatch
+func jsonContainsAt(path string, doc, subset any) (string, bool) {
+	switch s := subset.(type) {
+	case map[string]any:
+		d, ok := doc.(map[string]any)
+		if !ok {
+			return path, false
+		}
+		for k, sv := range s {
+			dv, ok := d[k]
+			if !ok {
+				return path + "." + k, false
+			}
+			if mismatch, ok := jsonContainsAt(path+"."+k, dv, sv); !ok {
+				return mismatch, false
+			}
+		}
+		return "", true
+	case []any:
+		d, ok := doc.([]any)
+		if !ok {
+			return path, false
+		}
+	elements:
+		for i, sv := range s {
+			for _, dv := range d {
+				if _, ok := jsonContainsAt(path, dv, sv); ok {
+					continue elements
+				}
+			}
+			return fmt.Sprintf("%s[%d]", path, i), false
+		}
+		return "", true
+	default:
+		return path, reflect.DeepEqual(doc, subset)
+	}
+}
+
+func jsonPath(doc, path string, expected any, data []any) (bool, []any) {
+	dv, err := decodeJSON("doc", doc)
+	if err != nil {
+		return false, append(data, "error", err)
+	}
+	actual, err := lookupJSONPath(dv, path)
+	if err != nil {
+		return false, append(data, "path", path, "error", err)
+	}
+
+	// round trip expected so Go values compare like the decoded document
+	raw, err := json.Marshal(expected)
+	if err != nil {
+		return false, append(data, "path", path, "error", err)
+	}
+	var want any
+	json.Unmarshal(raw, &want)
+	if reflect.DeepEqual(want, actual) {
+		return true, data
+	}
+	got, _ := json.Marshal(actual)
+	return false, append(data, "path", path, "expected", string(raw), "actual", string(got))
+}
+
+// lookupJSONPath returns the value at path in a decoded document
+func lookupJSONPath(doc any, path string) (any, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	current := doc
+	for i, segment := range segments {
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[segment]
+			if !ok {
+				return nil, fmt.Errorf("no key %q at %s", segment, strings.Join(append([]string{"$"}, segments[:i]...), "."))
+			}
+			current = next
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("no index %s in array of %d elements", segment, len(v))
+			}
+			current = v[index]
+		default:
+			return nil, fmt.Errorf("cannot descend into %s with %q", renderJSON(current), segment)
+		}
+	}
+	return current, nil
+}
+
+// parseJSONPath splits $.a[0]["b.c"] into a, 0 and b.c
+func parseJSONPath(path string) ([]string, error) {
+	rest := strings.TrimPrefix(path, "$")
+	var segments []string
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+		case strings.HasPrefix(rest, `["`):
+			end := strings.Index(rest, `"]`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated key in JSON path %q", path)
+			}
+			segments = append(segments, rest[2:end])
+			rest = rest[end+2:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index in JSON path %q", path)
+			}
+			segments = append(segments, rest[1:end])
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			segments = append(segments, rest[:end])
+			rest = rest[end:]
+		}
+	}
+	return segments, nil
+}
+
+func decodeJSON(name, doc string) (any, error) {
+	var v any
+	if err := json.Unmarshal([]byte(doc), &v); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %w", name, err)
+	}
+	return v, nil
+}
+
+func renderJSON(v any) string {
+	out, _ := json.Marshal(v)
+	return string(out)
+}
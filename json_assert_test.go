@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestJSONEq(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+
+	handler.JSONEq(ctx, `{"a": 1, "b": [true, null]}`, `{"b":[true,null],"a":1.0}`, "Same Document")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected key order, whitespace and number formatting to be ignored, got %q", buffer.String())
+	}
+
+	handler.JSONEq(ctx, `{"a": 1, "b": 2}`, `{"a": 1, "b": 3}`, "Different Document")
+	if !strings.Contains(buffer.String(), `-  "b": 2`) || !strings.Contains(buffer.String(), `+  "b": 3`) {
+		t.Fatalf("Expected a diff of the documents, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.JSONEq(ctx, `{}`, `{"a":`, "Broken Document")
+	if !strings.Contains(buffer.String(), "actual is not valid JSON") {
+		t.Fatalf("Expected invalid JSON to fail, got %q", buffer.String())
+	}
+}
+
+func TestJSONContains(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+	doc := `{"id": 7, "user": {"name": "ada", "roles": ["admin", "dev"]}, "items": [{"sku": "a", "n": 1}, {"sku": "b", "n": 2}]}`
+
+	handler.JSONContains(ctx, doc, `{"user": {"roles": ["dev"]}, "items": [{"sku": "b"}]}`, "Subset")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected the subset to match, got %q", buffer.String())
+	}
+
+	handler.JSONContains(ctx, doc, `{"user": {"name": "bob"}}`, "Wrong Value")
+	handler.JSONContains(ctx, doc, `{"items": [{"sku": "c"}]}`, "Missing Element")
+	if !strings.Contains(buffer.String(), "mismatch=$.user.name") || !strings.Contains(buffer.String(), "mismatch=$.items[0]") {
+		t.Fatalf("Expected the mismatching paths, got %q", buffer.String())
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+	doc := `{"items": [{"name": "pen", "tags": {"a.b": true}}], "total": 3}`
+
+	handler.JSONPath(ctx, doc, "$.items[0].name", "pen", "Name")
+	handler.JSONPath(ctx, doc, `items.0.tags["a.b"]`, true, "Quoted Key")
+	handler.JSONPath(ctx, doc, "total", 3, "Number")
+	handler.JSONPath(ctx, doc, "$.items", []map[string]any{{"name": "pen", "tags": map[string]bool{"a.b": true}}}, "Structure")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected the paths to match, got %q", buffer.String())
+	}
+
+	handler.JSONPath(ctx, doc, "$.total", 4, "Wrong Total")
+	if !strings.Contains(buffer.String(), "expected=4") || !strings.Contains(buffer.String(), "actual=3") {
+		t.Fatalf("Expected both values, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.JSONPath(ctx, doc, "$.items[1].name", "pen", "Out Of Range")
+	handler.JSONPath(ctx, doc, "$.missing", "pen", "Missing Key")
+	if !strings.Contains(buffer.String(), "no index 1 in array of 1 elements") || !strings.Contains(buffer.String(), `no key "missing" at $`) {
+		t.Fatalf("Expected lookup errors, got %q", buffer.String())
+	}
+}
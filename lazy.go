@@ -0,0 +1,28 @@
+package assert
+
+// LazyValue is an assertion data value computed only when the assertion fails
+type LazyValue func() any
+
+// Lazy defers computing an expensive data value until the assertion actually fails:
+//
+//	assert.Assert(ctx, ok, "cache inconsistent", "snapshot", assert.Lazy(cache.Snapshot))
+func Lazy(fn func() any) LazyValue {
+	return LazyValue(fn)
+}
+
+// AssertDataFunc adapts a plain function to the AssertData interface
+type AssertDataFunc func() string
+
+// Dump calls f
+func (f AssertDataFunc) Dump() string {
+	return f()
+}
+
+// resolveLazy replaces every LazyValue in data with its computed value
+func resolveLazy(data map[string]interface{}) {
+	for k, v := range data {
+		if lazy, ok := v.(LazyValue); ok {
+			data[k] = lazy()
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestLazyValuesOnlyOnFailure(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	calls := 0
+	snapshot := Lazy(func() any {
+		calls++
+		return "expensive-state"
+	})
+
+	handler.Assert(context.TODO(), true, "Passing Check", "snapshot", snapshot)
+	if calls != 0 {
+		t.Fatalf("Expected lazy value not to be computed on success")
+	}
+
+	handler.AddAssertData("db", AssertDataFunc(func() string { return "db-dump" }))
+	handler.Assert(context.TODO(), false, "Failing Check", "snapshot", snapshot)
+	if calls != 1 {
+		t.Fatalf("Expected lazy value to be computed once on failure, got %d", calls)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("snapshot=expensive-state")) || !bytes.Contains(buffer.Bytes(), []byte("db=db-dump")) {
+		t.Fatalf("Expected computed values in output, got %s", buffer.String())
+	}
+}
@@ -0,0 +1,145 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// mapKeySample bounds how many present keys a map failure lists
+const mapKeySample = 10
+
+// HasKey fails when the map m has no key equal to key
+func (a *AssertHandler) HasKey(ctx context.Context, m, key any, msg string, data ...any) {
+	ok, data := hasKey(m, key, true, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// HasKey fails through the default handler when the map m has no key equal to key
+func HasKey(ctx context.Context, m, key any, msg string, data ...any) {
+	ok, data := hasKey(m, key, true, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// NotHasKey fails when the map m has a key equal to key
+func (a *AssertHandler) NotHasKey(ctx context.Context, m, key any, msg string, data ...any) {
+	ok, data := hasKey(m, key, false, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// NotHasKey fails through the default handler when the map m has a key equal to key
+func NotHasKey(ctx context.Context, m, key any, msg string, data ...any) {
+	ok, data := hasKey(m, key, false, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// KeysEqual fails when the keys of the map m are not exactly the elements of the slice keys,
+// in any order, listing the missing and the unexpected keys
+func (a *AssertHandler) KeysEqual(ctx context.Context, m, keys any, msg string, data ...any) {
+	ok, data := keysEqual(m, keys, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// KeysEqual fails through the default handler when the keys of m are not exactly keys
+func KeysEqual(ctx context.Context, m, keys any, msg string, data ...any) {
+	ok, data := keysEqual(m, keys, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+func hasKey(m, key any, want bool, data []any) (bool, []any) {
+	mv := reflect.ValueOf(m)
+	if mv.Kind() != reflect.Map {
+		return false, append(data, "error", fmt.Sprintf("expected a map, got %T", m))
+	}
+	kv, err := mapKey(mv, key)
+	if err != nil {
+		return false, append(data, "key", key, "error", err)
+	}
+	if mv.MapIndex(kv).IsValid() == want {
+		return true, data
+	}
+	return false, append(data, "key", key, "keys", sampleKeys(mv), "length", mv.Len())
+}
+
+func keysEqual(m, keys any, data []any) (bool, []any) {
+	mv := reflect.ValueOf(m)
+	if mv.Kind() != reflect.Map {
+		return false, append(data, "error", fmt.Sprintf("expected a map, got %T", m))
+	}
+	kv := reflect.ValueOf(keys)
+	if kv.Kind() != reflect.Slice && kv.Kind() != reflect.Array {
+		return false, append(data, "error", fmt.Sprintf("expected a slice of keys, got %T", keys))
+	}
+
+	expected := make(map[any]bool, kv.Len())
+	var missing []any
+	for i := 0; i < kv.Len(); i++ {
+		key, err := mapKey(mv, kv.Index(i).Interface())
+		if err != nil {
+			return false, append(data, "key", kv.Index(i).Interface(), "error", err)
+		}
+		expected[key.Interface()] = true
+		if !mv.MapIndex(key).IsValid() {
+			missing = append(missing, key.Interface())
+		}
+	}
+	var extra []any
+	iter := mv.MapRange()
+	for iter.Next() {
+		if !expected[iter.Key().Interface()] {
+			extra = append(extra, iter.Key().Interface())
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return true, data
+	}
+	sortKeys(extra)
+	if len(missing) > 0 {
+		data = append(data, "missing", missing)
+	}
+	if len(extra) > 0 {
+		data = append(data, "extra", extra)
+	}
+	return false, data
+}
+
+// mapKey converts key to the key type of the map mv
+func mapKey(mv reflect.Value, key any) (reflect.Value, error) {
+	keyType := mv.Type().Key()
+	if key == nil {
+		switch keyType.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Chan:
+			return reflect.Zero(keyType), nil
+		}
+		return reflect.Value{}, fmt.Errorf("nil key for map with %s keys", keyType)
+	}
+	kv := reflect.ValueOf(key)
+	if kv.Type().AssignableTo(keyType) {
+		return kv, nil
+	}
+	// untyped constants such as 1 arrive as int, so convert between numeric types
+	if kv.CanConvert(keyType) && kv.Kind() != reflect.String && keyType.Kind() != reflect.String {
+		return kv.Convert(keyType), nil
+	}
+	return reflect.Value{}, fmt.Errorf("key of type %T for map with %s keys", key, keyType)
+}
+
+// sampleKeys returns up to mapKeySample keys of mv in a stable order
+func sampleKeys(mv reflect.Value) []any {
+	keys := make([]any, 0, mv.Len())
+	for _, k := range mv.MapKeys() {
+		keys = append(keys, k.Interface())
+	}
+	sortKeys(keys)
+	if len(keys) > mapKeySample {
+		keys = keys[:mapKeySample]
+	}
+	return keys
+}
+
+func sortKeys(keys []any) {
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+}
@@ -0,0 +1,52 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMapAssertions(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+
+	config := map[string]int{"port": 80, "workers": 4}
+	handler.HasKey(ctx, config, "port", "Port Missing")
+	handler.NotHasKey(ctx, config, "debug", "Debug Set")
+	handler.KeysEqual(ctx, config, []string{"workers", "port"}, "Unexpected Keys")
+	handler.HasKey(ctx, map[int64]bool{7: true}, 7, "Shard Missing")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	large := map[string]int{}
+	for i := 0; i < 20; i++ {
+		large[fmt.Sprintf("key%02d", i)] = i
+	}
+	handler.HasKey(ctx, large, "port", "Port Missing")
+	if !strings.Contains(buffer.String(), "key=port") || !strings.Contains(buffer.String(), "keys=[key00") ||
+		strings.Contains(buffer.String(), "key10") || !strings.Contains(buffer.String(), "length=20") {
+		t.Fatalf("Expected the missing key and a bounded sample, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.NotHasKey(ctx, config, "port", "Port Set")
+	if !strings.Contains(buffer.String(), "key=port") {
+		t.Fatalf("Expected the present key, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.KeysEqual(ctx, config, []string{"port", "host"}, "Unexpected Keys")
+	if !strings.Contains(buffer.String(), "missing=[host]") || !strings.Contains(buffer.String(), "extra=[workers]") {
+		t.Fatalf("Expected missing and extra keys, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.HasKey(ctx, config, 1, "Wrong Key Type")
+	if !strings.Contains(buffer.String(), "key of type int for map with string keys") {
+		t.Fatalf("Expected a key type error, got %q", buffer.String())
+	}
+}
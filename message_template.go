@@ -0,0 +1,65 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// msgTemplateKey holds the message before its placeholders were filled in
+const msgTemplateKey = "msg_template"
+
+// expandMessage fills the {name} placeholders of msg with the data values of the same name,
+// e.g. "user {user_id} exceeded quota {quota}". Placeholders without data are left as they
+// are. It reports whether anything was filled in.
+func expandMessage(msg string, data map[string]interface{}) (string, bool) {
+	if !strings.Contains(msg, "{") {
+		return msg, false
+	}
+
+	var b strings.Builder
+	expanded := false
+	rest := msg
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		name := rest[start+1 : end]
+		value, ok := data[name]
+		if !ok || !isPlaceholderName(name) {
+			// keep the brace and look for a placeholder after it
+			b.WriteString(rest[:start+1])
+			rest = rest[start+1:]
+			continue
+		}
+		b.WriteString(rest[:start])
+		fmt.Fprint(&b, value)
+		rest = rest[end+1:]
+		expanded = true
+	}
+	if !expanded {
+		return msg, false
+	}
+	b.WriteString(rest)
+	return b.String(), true
+}
+
+func isPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '.' || r == '-'):
+		default:
+			return false
+		}
+	}
+	return true
+}
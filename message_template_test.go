@@ -0,0 +1,51 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExpandMessage(t *testing.T) {
+	data := map[string]interface{}{"user_id": 42, "quota": "10GB", "a.b": true}
+	cases := map[string]string{
+		"user {user_id} exceeded quota {quota}": "user 42 exceeded quota 10GB",
+		"{a.b} and {missing} and {user_id}":     "true and {missing} and 42",
+		"{{user_id}} {not a name} {":            "{42} {not a name} {",
+	}
+	for msg, want := range cases {
+		if got, ok := expandMessage(msg, data); got != want || !ok {
+			t.Fatalf("expandMessage(%q) = %q, %v; want %q", msg, got, ok, want)
+		}
+	}
+	if got, ok := expandMessage("no {placeholders} here", data); ok || got != "no {placeholders} here" {
+		t.Fatalf("Expected unknown placeholders to be kept, got %q", got)
+	}
+}
+
+func TestMessageTemplate(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithDeferMode(true))
+	ctx := context.TODO()
+
+	for _, user := range []int{1, 2} {
+		handler.Assert(ctx, false, "user {user_id} exceeded quota {quota}", "user_id", user, "quota", 5)
+	}
+	handler.Assert(ctx, false, "Plain")
+
+	events := handler.DeferredEvents()
+	handler.ClearDeferred()
+	if events[0].Message != "user 1 exceeded quota 5" || events[0].Data["msg"] != events[0].Message {
+		t.Fatalf("Expected the filled in message, got %q", events[0].Message)
+	}
+	if events[0].Data["msg_template"] != "user {user_id} exceeded quota {quota}" || events[0].Fingerprint != events[1].Fingerprint {
+		t.Fatalf("Expected the template to be kept and to group both failures, got %v", events[0].Data)
+	}
+	if _, ok := events[2].Data["msg_template"]; ok {
+		t.Fatalf("Expected no template for messages without placeholders")
+	}
+	if !strings.Contains(buffer.String(), "msg_template=user {user_id} exceeded quota {quota}") {
+		t.Fatalf("Expected the template in the output, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,105 @@
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+// WithStrictControlFlow panics with the *AssertionError whenever the exit function returns
+// after a fatal failure, so code never keeps running past a failed assertion
+func WithStrictControlFlow() Option {
+	return func(a *AssertHandler) {
+		a.strictControlFlow = true
+	}
+}
+
+// mustFail panics after a failed Must* check whose report returned control to the caller
+func mustFail(msg string, data []any) {
+	fields := map[string]interface{}{}
+	for i := 0; i+1 < len(data); i += 2 {
+		if key, ok := data[i].(string); ok {
+			fields[key] = data[i+1]
+		}
+	}
+	panic(&AssertionError{Msg: msg, Data: fields, Frames: framesOf(callerFrames()), Time: time.Now()})
+}
+
+// MustAssert is Assert that never returns when truth is false
+func (a *AssertHandler) MustAssert(ctx context.Context, truth bool, msg string, data ...any) {
+	if truth {
+		a.passed(KindAssert)
+		return
+	}
+	a.Assert(ctx, truth, msg, data...)
+	mustFail(msg, data)
+}
+
+// MustNil is Nil that never returns when item is not nil
+func (a *AssertHandler) MustNil(ctx context.Context, item any, msg string, data ...any) {
+	if isNil(item) {
+		a.passed(KindAssert)
+		return
+	}
+	a.Nil(ctx, item, msg, data...)
+	mustFail(msg, data)
+}
+
+// MustNotNil is NotNil that never returns when item is nil
+func (a *AssertHandler) MustNotNil(ctx context.Context, item any, msg string, data ...any) {
+	if !isNil(item) {
+		a.passed(KindAssert)
+		return
+	}
+	a.NotNil(ctx, item, msg, data...)
+	mustFail(msg, data)
+}
+
+// MustNoError is NoError that never returns when err is not nil
+func (a *AssertHandler) MustNoError(ctx context.Context, err error, msg string, data ...any) {
+	if err == nil {
+		a.passed(KindAssert)
+		return
+	}
+	a.NoError(ctx, err, msg, data...)
+	mustFail(msg, append(data, "error", err))
+}
+
+// MustAssert is Assert through the default handler that never returns when truth is false
+func MustAssert(ctx context.Context, truth bool, msg string, data ...any) {
+	if truth {
+		passedFor(ctx, KindAssert)
+		return
+	}
+	handlerFor(ctx).Assert(ctx, truth, msg, data...)
+	mustFail(msg, data)
+}
+
+// MustNil is Nil through the default handler that never returns when item is not nil
+func MustNil(ctx context.Context, item any, msg string, data ...any) {
+	if isNil(item) {
+		passedFor(ctx, KindAssert)
+		return
+	}
+	handlerFor(ctx).Nil(ctx, item, msg, data...)
+	mustFail(msg, data)
+}
+
+// MustNotNil is NotNil through the default handler that never returns when item is nil
+func MustNotNil(ctx context.Context, item any, msg string, data ...any) {
+	if !isNil(item) {
+		passedFor(ctx, KindAssert)
+		return
+	}
+	handlerFor(ctx).NotNil(ctx, item, msg, data...)
+	mustFail(msg, data)
+}
+
+// MustNoError is NoError through the default handler that never returns when err is not nil
+func MustNoError(ctx context.Context, err error, msg string, data ...any) {
+	if err == nil {
+		passedFor(ctx, KindAssert)
+		return
+	}
+	handlerFor(ctx).NoError(ctx, err, msg, data...)
+	mustFail(msg, append(data, "error", err))
+}
@@ -0,0 +1,36 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMustNoErrorNeverReturns(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	defer func() {
+		err, ok := recover().(*AssertionError)
+		if !ok || err.Msg != "Must Load" {
+			t.Fatalf("Expected MustNoError to panic with an AssertionError, got %v", err)
+		}
+	}()
+	handler.MustNoError(context.TODO(), errors.New("missing"), "Must Load")
+	t.Fatalf("Expected MustNoError not to return")
+}
+
+func TestStrictControlFlow(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithStrictControlFlow())
+
+	defer func() {
+		if _, ok := recover().(*AssertionError); !ok || exits != 1 {
+			t.Fatalf("Expected strict handler to panic after the exit function returned")
+		}
+	}()
+	handler.Assert(context.TODO(), false, "Strict Failure")
+	t.Fatalf("Expected Assert not to return in strict mode")
+}
@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	namedMu       sync.Mutex
+	namedHandlers = map[string]*AssertHandler{}
+)
+
+// Named returns the handler registered for a subsystem, creating it on first use from the
+// current default handler's configuration. Every failure it reports carries a "subsystem" field.
+// SetDefaultHandler forgets the handlers created so far, so they are derived from the new
+// default handler on next use.
+func Named(name string) *AssertHandler {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	if h, ok := namedHandlers[name]; ok {
+		return h
+	}
+	h := DefaultHandler().WithTags("subsystem", name)
+	namedHandlers[name] = h
+	return h
+}
+
+// NamedHandlers returns the names of all subsystems created through Named, sorted
+func NamedHandlers() []string {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	names := make([]string, 0, len(namedHandlers))
+	for name := range namedHandlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResetNamed forgets the handlers created by Named, e.g. so tests do not share subsystem
+// handlers. Handlers already returned keep working.
+func ResetNamed() {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+	namedHandlers = map[string]*AssertHandler{}
+}
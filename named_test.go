@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestNamedHandler(t *testing.T) {
+	defer SetDefaultHandler(nil)
+
+	var buffer bytes.Buffer
+	base := NewAssertHandler()
+	base.ToWriter(&buffer)
+	base.SetExitFunc(func(code int) {})
+	SetDefaultHandler(base)
+
+	billing := Named("billing")
+	if Named("billing") != billing {
+		t.Fatalf("Expected Named to return the same handler for the same name")
+	}
+
+	billing.Assert(context.TODO(), false, "Invoice Total Mismatch")
+
+	if !bytes.Contains(buffer.Bytes(), []byte("subsystem=billing")) {
+		t.Fatalf("Expected subsystem field in output")
+	}
+
+	found := false
+	for _, name := range NamedHandlers() {
+		found = found || name == "billing"
+	}
+	if !found {
+		t.Fatalf("Expected billing in NamedHandlers")
+	}
+}
+
+func TestNamedFollowsDefaultHandler(t *testing.T) {
+	defer SetDefaultHandler(nil)
+
+	install := func(buffer *bytes.Buffer) {
+		h := NewAssertHandler()
+		h.ToWriter(buffer)
+		h.SetExitFunc(func(code int) {})
+		SetDefaultHandler(h)
+	}
+
+	var first, second bytes.Buffer
+	install(&first)
+	Named("billing").Assert(context.TODO(), false, "First Default")
+
+	install(&second)
+	Named("billing").Assert(context.TODO(), false, "Second Default")
+	if bytes.Contains(first.Bytes(), []byte("Second Default")) || !bytes.Contains(second.Bytes(), []byte("subsystem=billing")) {
+		t.Fatalf("Expected Named to derive from the new default handler, got %q", second.String())
+	}
+
+	ResetNamed()
+	if names := NamedHandlers(); len(names) != 0 {
+		t.Fatalf("Expected ResetNamed to forget the subsystems, got %v", names)
+	}
+}
@@ -0,0 +1,41 @@
+package assert
+
+import "strings"
+
+// namespaceSeparator splits AssertData keys into a namespace path and a name
+const namespaceSeparator = "/"
+
+// RemoveAssertDataNamespace removes all AssertData registered under namespace
+func (a *AssertHandler) RemoveAssertDataNamespace(namespace string) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+
+	prefix := strings.TrimSuffix(namespace, namespaceSeparator) + namespaceSeparator
+	for key := range a.assertData {
+		if strings.HasPrefix(key, prefix) {
+			delete(a.assertData, key)
+		}
+	}
+}
+
+// setNamespaced stores value in data at the path described by key, creating nested maps
+// for each namespace segment. If a segment is already taken by a non-namespace value the
+// full key is stored flat instead so nothing is overwritten.
+func setNamespaced(data map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, namespaceSeparator)
+	current := data
+	for _, part := range parts[:len(parts)-1] {
+		switch next := current[part].(type) {
+		case map[string]interface{}:
+			current = next
+		case nil:
+			nested := map[string]interface{}{}
+			current[part] = nested
+			current = nested
+		default:
+			data[key] = value
+			return
+		}
+	}
+	current[parts[len(parts)-1]] = value
+}
@@ -0,0 +1,39 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type staticData string
+
+func (s staticData) Dump() string { return string(s) }
+
+func TestNamespacedAssertData(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFormatter(&JSONFormatter{}))
+	handler.AddAssertData("mylib/cache", staticData("cache-state"))
+	handler.AddAssertData("mylib/pool", staticData("pool-state"))
+	handler.AddAssertData("otherlib/cache", staticData("other-cache"))
+
+	handler.Assert(context.TODO(), false, "Namespaced Data")
+
+	if !bytes.Contains(buffer.Bytes(), []byte(`"mylib": {`)) {
+		t.Fatalf("Expected data nested under mylib, got %s", buffer.String())
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte(`"cache": "other-cache"`)) {
+		t.Fatalf("Expected otherlib cache not to collide with mylib cache")
+	}
+
+	buffer.Reset()
+	handler.RemoveAssertDataNamespace("mylib")
+	handler.Assert(context.TODO(), false, "Namespaced Data")
+
+	if bytes.Contains(buffer.Bytes(), []byte("cache-state")) || bytes.Contains(buffer.Bytes(), []byte("pool-state")) {
+		t.Fatalf("Expected mylib data to be removed")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("other-cache")) {
+		t.Fatalf("Expected otherlib data to remain")
+	}
+}
@@ -0,0 +1,19 @@
+package assert
+
+import "reflect"
+
+// isNil reports whether item is nil or an interface holding a nil pointer, map, slice,
+// channel, func or interface
+func isNil(item any) bool {
+	if item == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(item)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,41 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestNilHandlesTypedNil(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+
+	var p *bytes.Buffer
+	var m map[string]int
+	var s []int
+	var f func()
+
+	handler.NotNil(context.TODO(), p, "Typed Nil Pointer")
+	handler.NotNil(context.TODO(), m, "Nil Map")
+	handler.NotNil(context.TODO(), s, "Nil Slice")
+	handler.NotNil(context.TODO(), f, "Nil Func")
+	if exits != 4 {
+		t.Fatalf("Expected every typed nil to fail NotNil, got %d failures", exits)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("type=*bytes.Buffer")) {
+		t.Fatalf("Expected dynamic type in output")
+	}
+
+	exits = 0
+	handler.Nil(context.TODO(), p, "Typed Nil Pointer")
+	handler.Nil(context.TODO(), nil, "Untyped Nil")
+	if exits != 0 {
+		t.Fatalf("Expected typed and untyped nil to pass Nil, got %d failures", exits)
+	}
+
+	handler.Nil(context.TODO(), &buffer, "Non Nil Pointer")
+	if exits != 1 {
+		t.Fatalf("Expected non-nil pointer to fail Nil")
+	}
+}
@@ -0,0 +1,73 @@
+package assert
+
+import (
+	"context"
+	"sync"
+)
+
+// callSiteCounts counts the failures of each call site, keyed by its program counter.
+// Derived handlers share their parent's counts.
+type callSiteCounts struct {
+	mu     sync.Mutex
+	counts map[uintptr]int
+}
+
+func newCallSiteCounts() *callSiteCounts {
+	return &callSiteCounts{counts: make(map[uintptr]int)}
+}
+
+// allow counts a failure at pc and reports whether it is among the first limit ones
+func (c *callSiteCounts) allow(pc uintptr, limit int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[pc]++
+	return c.counts[pc] <= limit
+}
+
+// WithReportOnce reports the failures of each call site only the first time, avoiding log
+// storms from an invariant that stays broken inside a loop
+func WithReportOnce() Option {
+	return WithReportFirst(1)
+}
+
+// WithReportFirst reports the failures of each call site, keyed by its program counter, only
+// the first n times. Later failures of the call site are dropped without running the exit
+// path. Zero or less reports every failure.
+func WithReportFirst(n int) Option {
+	return func(a *AssertHandler) {
+		a.reportFirst = n
+	}
+}
+
+// Once is Assert reporting the failures of its call site only the first time
+func (a *AssertHandler) Once(ctx context.Context, truth bool, msg string, data ...any) {
+	if truth {
+		a.passed(KindAssert)
+		return
+	}
+	a.With(WithReportOnce()).evaluate(ctx, KindAssert, false, msg, data)
+}
+
+// Once is Assert through the handler on ctx or the default handler, reporting the failures
+// of its call site only the first time. Asserters other than *AssertHandler report every
+// failure.
+func Once(ctx context.Context, truth bool, msg string, data ...any) {
+	a := handlerFor(ctx)
+	if h, ok := a.(*AssertHandler); ok {
+		h.Once(ctx, truth, msg, data...)
+		return
+	}
+	a.Assert(ctx, truth, msg, data...)
+}
+
+// limitCallSite reports whether the failure being reported exceeds the limit of its call site
+func (a *AssertHandler) limitCallSite() bool {
+	if a.reportFirst <= 0 {
+		return false
+	}
+	frame, ok := callSite()
+	if !ok {
+		return false
+	}
+	return !a.callSites.allow(frame.PC, a.reportFirst)
+}
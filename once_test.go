@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestReportFirst(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithReportFirst(2))
+
+	for i := 0; i < 5; i++ {
+		handler.Assert(context.TODO(), false, "Loop Invariant", "i", i)
+	}
+	handler.Assert(context.TODO(), false, "Other Site")
+
+	if n := strings.Count(buffer.String(), "msg=Loop Invariant"); n != 2 {
+		t.Fatalf("Expected the call site to be reported twice, got %d:\n%s", n, buffer.String())
+	}
+	if !strings.Contains(buffer.String(), "msg=Other Site") || exits != 3 {
+		t.Fatalf("Expected other call sites to be counted apart and dropped failures not to exit, got %d exits", exits)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+
+	for i := 0; i < 3; i++ {
+		Once(ctx, false, "Package Once")
+		handler.Once(ctx, i == 1, "Method Once")
+	}
+	handler.Assert(ctx, false, "Plain Assert")
+	handler.Assert(ctx, false, "Plain Assert")
+
+	output := buffer.String()
+	if strings.Count(output, "msg=Package Once") != 1 || strings.Count(output, "msg=Method Once") != 1 {
+		t.Fatalf("Expected each Once call site to be reported once:\n%s", output)
+	}
+	if strings.Count(output, "msg=Plain Assert") != 2 {
+		t.Fatalf("Expected Once not to limit other assertions")
+	}
+}
@@ -0,0 +1,85 @@
+package assert
+
+import (
+	"fmt"
+	"io"
+)
+
+// Option configures an AssertHandler
+type Option func(*AssertHandler)
+
+// WithFormatter sets the formatter used for failure output
+func WithFormatter(formatter Formatter) Option {
+	return func(a *AssertHandler) {
+		a.formatter = asEventFormatter(formatter)
+	}
+}
+
+// WithEventFormatter sets a formatter working on the typed event
+func WithEventFormatter(formatter EventFormatter) Option {
+	return func(a *AssertHandler) {
+		a.formatter = formatter
+	}
+}
+
+// WithWriter sets the writer failures are written to
+func WithWriter(w io.Writer) Option {
+	return func(a *AssertHandler) {
+		a.writer = w
+	}
+}
+
+// WithExitFunc sets the function called with the exit code after a failure. It is shorthand
+// for WithFailurePolicy(ExitPolicy{Exit: exitFunc}).
+func WithExitFunc(exitFunc func(int)) Option {
+	return WithFailurePolicy(ExitPolicy{Exit: exitFunc})
+}
+
+// WithDeferMode toggles deferred assertion mode
+func WithDeferMode(deferMode bool) Option {
+	return func(a *AssertHandler) {
+		a.deferAssertions = deferMode
+	}
+}
+
+// WithAssertFlush registers a flusher run before each failure is reported
+func WithAssertFlush(flusher AssertFlush) Option {
+	return func(a *AssertHandler) {
+		a.flushes = append(a.flushes, flusher)
+	}
+}
+
+// WithExporter registers an exporter that receives every assertion event
+func WithExporter(exporter Exporter) Option {
+	return func(a *AssertHandler) {
+		a.exporters = append(a.exporters, exporter)
+	}
+}
+
+func (a *AssertHandler) apply(opts []Option) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Configure permanently applies opts to the default handler used by the package-level
+// functions. It is safe to call before or after the first assertion; the options are applied
+// atomically with respect to concurrent assertions and handler replacement. If failures were
+// already reported with the previous configuration a warning is written.
+func Configure(opts ...Option) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultHandler == nil {
+		defaultHandler = NewAssertHandler()
+	}
+	h := defaultHandler
+
+	h.flushLock.Lock()
+	defer h.flushLock.Unlock()
+	h.apply(opts)
+
+	if n := h.reported.Load(); n > 0 {
+		fmt.Fprintf(h.writer, "assert: WARNING: Configure called after %d failures were reported with the previous configuration\n", n)
+	}
+}
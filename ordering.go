@@ -0,0 +1,77 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// defaultKeyOrder is the order of the leading keys when a formatter has no KeyOrder
+var defaultKeyOrder = []string{"msg"}
+
+// orderedKeys returns the keys of data with the keys listed in order first, in that order,
+// followed by the remaining keys sorted. A nil order means msg first.
+func orderedKeys(data map[string]interface{}, order []string) []string {
+	if order == nil {
+		order = defaultKeyOrder
+	}
+
+	keys := make([]string, 0, len(data))
+	listed := make(map[string]bool, len(order))
+	for _, k := range order {
+		if _, ok := data[k]; ok && !listed[k] {
+			keys = append(keys, k)
+		}
+		listed[k] = true
+	}
+
+	rest := make([]string, 0, len(data))
+	for k := range data {
+		if !listed[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// orderedJSON is a JSON object that keeps its keys in the given order
+type orderedJSON struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o orderedJSON) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// orderedYAML converts data to a yaml.MapSlice with its keys in the given order
+func orderedYAML(data map[string]interface{}, order []string) yaml.MapSlice {
+	keys := orderedKeys(data, order)
+	out := make(yaml.MapSlice, len(keys))
+	for i, k := range keys {
+		out[i] = yaml.MapItem{Key: k, Value: data[k]}
+	}
+	return out
+}
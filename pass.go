@@ -0,0 +1,30 @@
+package assert
+
+import "context"
+
+// passer is implemented by Asserters that record passing checks
+type passer interface {
+	Pass(ctx context.Context, code, msg string, data ...any)
+}
+
+// Pass records that the invariant identified by code was checked and held. It writes nothing;
+// the count is available from Passes, in the Taxonomy entries of the code, in Stats and to
+// decorators such as WithMetricsDecorator, so dashboards can show pass/fail ratios.
+func (a *AssertHandler) Pass(ctx context.Context, code, msg string, data ...any) {
+	a.taxonomy.pass(code)
+	a.stats.record(KindAssert, callSiteLocation(), true, a.countPasses.Load())
+}
+
+// Pass records a passing check through the handler on ctx or the default handler
+func Pass(ctx context.Context, code, msg string, data ...any) {
+	if p, ok := handlerFor(ctx).(passer); ok {
+		p.Pass(ctx, code, msg, data...)
+	}
+}
+
+// Passes returns how many passing checks were recorded for code
+func (a *AssertHandler) Passes(code string) int64 {
+	a.taxonomy.mu.Lock()
+	defer a.taxonomy.mu.Unlock()
+	return a.taxonomy.passes[code]
+}
@@ -0,0 +1,41 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestPass(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewIsolatedHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	var calls []string
+	metrics := WithMetricsDecorator(func(method string, failed bool) {
+		if !failed {
+			calls = append(calls, method)
+		}
+	})
+	ctx := NewContext(context.TODO(), WrapHandler(handler, metrics))
+
+	handler.Pass(context.TODO(), "INV-1", "Ledger Balanced")
+	Pass(ctx, "INV-1", "Ledger Balanced")
+	handler.Assert(context.TODO(), false, "Ledger Unbalanced", "code", "INV-1")
+
+	if handler.Passes("INV-1") != 2 {
+		t.Fatalf("Expected two passes, got %d", handler.Passes("INV-1"))
+	}
+	if len(calls) != 1 || calls[0] != "Pass" {
+		t.Fatalf("Expected the metrics decorator to see the pass, got %v", calls)
+	}
+	entries := handler.Taxonomy()
+	if len(entries) != 1 || entries[0].Passes != 2 || entries[0].Count != 1 {
+		t.Fatalf("Expected passes next to failures in the taxonomy, got %+v", entries)
+	}
+
+	buffer.Reset()
+	handler.Pass(context.TODO(), "INV-2", "Quiet")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected passes to write nothing, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,185 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// prettyMaxDepth bounds how deep prettyPrint descends into nested values
+const prettyMaxDepth = 32
+
+// refKey identifies a pointer, map or slice that can take part in a cycle
+type refKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func refOf(v reflect.Value) (refKey, bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map:
+		if !v.IsNil() {
+			return refKey{v.Type(), v.Pointer()}, true
+		}
+	case reflect.Slice:
+		if v.Len() > 0 {
+			return refKey{v.Type(), v.Pointer()}, true
+		}
+	}
+	return refKey{}, false
+}
+
+// cycleTargets walks v and returns the references that are reached again from inside
+// themselves, in the order they are first entered
+func cycleTargets(v reflect.Value) []refKey {
+	var targets []refKey
+	seen := map[refKey]bool{}
+	onPath := map[refKey]bool{}
+
+	var walk func(v reflect.Value, depth int)
+	walk = func(v reflect.Value, depth int) {
+		if !v.IsValid() || depth > prettyMaxDepth {
+			return
+		}
+		if ref, ok := refOf(v); ok {
+			if onPath[ref] {
+				if !seen[ref] {
+					seen[ref] = true
+					targets = append(targets, ref)
+				}
+				return
+			}
+			onPath[ref] = true
+			defer delete(onPath, ref)
+		}
+
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			walk(v.Elem(), depth+1)
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				walk(v.Field(i), depth+1)
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i), depth+1)
+			}
+		case reflect.Map:
+			iter := v.MapRange()
+			for iter.Next() {
+				walk(iter.Value(), depth+1)
+			}
+		}
+	}
+	walk(v, 0)
+	return targets
+}
+
+// hasCycle reports whether v refers back to itself through pointers, maps or slices
+func hasCycle(v any) bool {
+	return len(cycleTargets(reflect.ValueOf(v))) > 0
+}
+
+// prettyPrint renders v like %+v, but terminates on self-referential values: the target of
+// a cycle is prefixed with #n and references back to it render as <cycle to #n>
+func prettyPrint(v any) string {
+	rv := reflect.ValueOf(v)
+	p := &printer{ids: map[refKey]int{}, onPath: map[refKey]bool{}}
+	for i, ref := range cycleTargets(rv) {
+		p.ids[ref] = i + 1
+	}
+	p.print(rv, 0)
+	return p.b.String()
+}
+
+type printer struct {
+	b      strings.Builder
+	ids    map[refKey]int
+	onPath map[refKey]bool
+}
+
+func (p *printer) print(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		p.b.WriteString("<nil>")
+		return
+	}
+	if depth > prettyMaxDepth {
+		p.b.WriteString("...")
+		return
+	}
+
+	if ref, ok := refOf(v); ok {
+		if id, ok := p.ids[ref]; ok {
+			if p.onPath[ref] {
+				fmt.Fprintf(&p.b, "<cycle to #%d>", id)
+				return
+			}
+			fmt.Fprintf(&p.b, "#%d ", id)
+		}
+		p.onPath[ref] = true
+		defer delete(p.onPath, ref)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			p.b.WriteString("<nil>")
+			return
+		}
+		p.b.WriteString("&")
+		p.print(v.Elem(), depth+1)
+	case reflect.Interface:
+		p.print(v.Elem(), depth+1)
+	case reflect.Struct:
+		p.b.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				p.b.WriteString(" ")
+			}
+			p.b.WriteString(v.Type().Field(i).Name)
+			p.b.WriteString(":")
+			p.print(v.Field(i), depth+1)
+		}
+		p.b.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		p.b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				p.b.WriteString(" ")
+			}
+			p.print(v.Index(i), depth+1)
+		}
+		p.b.WriteString("]")
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		p.b.WriteString("map[")
+		for i, k := range keys {
+			if i > 0 {
+				p.b.WriteString(" ")
+			}
+			p.print(k, depth+1)
+			p.b.WriteString(":")
+			p.print(v.MapIndex(k), depth+1)
+		}
+		p.b.WriteString("]")
+	default:
+		if v.CanInterface() {
+			fmt.Fprintf(&p.b, "%v", v.Interface())
+			return
+		}
+		fmt.Fprintf(&p.b, "%v", v)
+	}
+}
+
+// renderValue formats a data value for text output, switching to prettyPrint for
+// self-referential values that would make fmt recurse forever
+func renderValue(v any) string {
+	if hasCycle(v) {
+		return prettyPrint(v)
+	}
+	return fmt.Sprint(v)
+}
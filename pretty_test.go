@@ -0,0 +1,56 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func TestPrettyPrintCycles(t *testing.T) {
+	list := &node{Value: 1, Next: &node{Value: 2}}
+	list.Next.Next = list
+
+	if out := prettyPrint(list); out != "#1 &{Value:1 Next:&{Value:2 Next:<cycle to #1>}}" {
+		t.Fatalf("Unexpected rendering of a cyclic list: %q", out)
+	}
+	if out := prettyPrint(&node{Value: 1, Next: &node{Value: 2}}); out != "&{Value:1 Next:&{Value:2 Next:<nil>}}" {
+		t.Fatalf("Unexpected rendering of an acyclic list: %q", out)
+	}
+
+	self := map[string]interface{}{"name": "root"}
+	self["self"] = self
+	if out := prettyPrint(self); out != "#1 map[name:root self:<cycle to #1>]" {
+		t.Fatalf("Unexpected rendering of a self-referential map: %q", out)
+	}
+
+	// shared but acyclic references are not cycles
+	shared := &node{Value: 7}
+	if hasCycle([]*node{shared, shared}) {
+		t.Fatalf("Expected shared references not to count as a cycle")
+	}
+}
+
+func TestFormattersSurviveCycles(t *testing.T) {
+	self := map[string]interface{}{"name": "root"}
+	self["self"] = self
+
+	for name, formatter := range map[string]Formatter{
+		"text": &TextFormatter{},
+		"json": &JSONFormatter{},
+		"yaml": &YAMLFormatter{},
+		"cli":  &CLIFormatter{Verbosity: VerbosityNormal, NoColor: true},
+	} {
+		var buffer bytes.Buffer
+		handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFormatter(formatter))
+		handler.Assert(context.TODO(), false, "Cyclic Data", "tree", self)
+		if !strings.Contains(buffer.String(), "cycle to #1") {
+			t.Fatalf("%s: expected the cycle to be rendered, got %q", name, buffer.String())
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+)
+
+// readerChunkSize is how much ReaderContains reads at a time
+const readerChunkSize = 32 * 1024
+
+// ReaderContains streams r looking for substr without loading the whole input into memory.
+// It fails when substr is not found before EOF, a read error, or ctx being done, reporting the
+// byte offset reached.
+func (a *AssertHandler) ReaderContains(ctx context.Context, r io.Reader, substr, msg string, data ...any) {
+	ok, data := readerContains(ctx, r, substr, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// ReaderContains streams r looking for substr through the default handler
+func ReaderContains(ctx context.Context, r io.Reader, substr, msg string, data ...any) {
+	ok, data := readerContains(ctx, r, substr, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+func readerContains(ctx context.Context, r io.Reader, substr string, data []any) (bool, []any) {
+	offset, err := readerIndex(ctx, r, []byte(substr))
+	if offset >= 0 && err == nil {
+		return true, data
+	}
+
+	data = append(data, "substr", substr, "bytes_scanned", -offset-1)
+	if err != nil {
+		data = append(data, "error", err)
+	}
+	return false, data
+}
+
+// readerIndex returns the offset of the first match of sep in r. When there is no match it
+// returns -(bytes scanned)-1 together with any error other than io.EOF.
+func readerIndex(ctx context.Context, r io.Reader, sep []byte) (int64, error) {
+	if len(sep) == 0 {
+		return 0, nil
+	}
+
+	buf := make([]byte, 0, readerChunkSize+len(sep))
+	chunk := make([]byte, readerChunkSize)
+	var base int64 // offset in r of buf[0]
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return -(base + int64(len(buf))) - 1, err
+		}
+
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if i := bytes.Index(buf, sep); i >= 0 {
+			return base + int64(i), nil
+		}
+
+		// keep only the tail that could start a match spanning into the next chunk
+		if keep := len(sep) - 1; len(buf) > keep {
+			drop := len(buf) - keep
+			base += int64(drop)
+			buf = append(buf[:0], buf[drop:]...)
+		}
+
+		if err != nil {
+			scanned := base + int64(len(buf))
+			if errors.Is(err, io.EOF) {
+				return -scanned - 1, nil
+			}
+			return -scanned - 1, err
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReaderContains(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+
+	// place the needle across a chunk boundary
+	input := strings.Repeat("a", readerChunkSize-3) + "needle" + strings.Repeat("b", 10)
+	handler.ReaderContains(context.TODO(), strings.NewReader(input), "needle", "Needle Missing")
+	if exits != 0 {
+		t.Fatalf("Expected needle spanning chunks to be found")
+	}
+
+	large := io.LimitReader(zeroReader{}, 3*readerChunkSize)
+	handler.ReaderContains(context.TODO(), large, "needle", "Needle Missing")
+	if exits != 1 {
+		t.Fatalf("Expected missing needle to fail")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("bytes_scanned=98304")) {
+		t.Fatalf("Expected scanned offset in output, got %s", buffer.String())
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
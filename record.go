@@ -0,0 +1,94 @@
+package assert
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RecordFraming selects how failure records are delimited on the handler's writers
+type RecordFraming int
+
+const (
+	// FramingBlock writes an "ASSERT" line, the formatted failure and a newline, for humans.
+	// Deferred failures are written as one block separated by "---" lines. This is the default.
+	FramingBlock RecordFraming = iota
+	// FramingNewline writes each failure on a single line, escaping the newlines of
+	// multi-line output as \n, for journald and line-based log shippers
+	FramingNewline
+	// FramingNUL terminates each failure with a NUL byte, for consumers splitting a stream on NUL
+	FramingNUL
+	// FramingLengthPrefix precedes each failure with its length as a 4-byte big-endian
+	// integer, for TCP inputs reading length-prefixed frames
+	FramingLengthPrefix
+)
+
+// WithRecordFraming sets how failures are delimited on the handler's writers
+func WithRecordFraming(framing RecordFraming) Option {
+	return func(a *AssertHandler) {
+		a.framing = framing
+	}
+}
+
+// FlushMode selects when writers buffering output are flushed
+type FlushMode int
+
+const (
+	// FlushBuffered flushes writers on Shutdown and before exiting. This is the default.
+	FlushBuffered FlushMode = iota
+	// FlushEveryEvent flushes writers after every failure, so pipe consumers see failures
+	// as they happen even when the process never exits
+	FlushEveryEvent
+)
+
+// WithFlushMode sets when writers with a Flush() error or Flush(context.Context) error
+// method, such as bufio.Writer and AsyncWriter, are flushed
+func WithFlushMode(mode FlushMode) Option {
+	return func(a *AssertHandler) {
+		a.flushMode = mode
+	}
+}
+
+// record frames one formatted failure
+func (f RecordFraming) record(formatted string) []byte {
+	switch f {
+	case FramingNewline:
+		line := strings.ReplaceAll(strings.TrimRight(formatted, "\n"), "\n", `\n`)
+		return []byte(line + "\n")
+	case FramingNUL:
+		return []byte(formatted + "\x00")
+	case FramingLengthPrefix:
+		out := binary.BigEndian.AppendUint32(nil, uint32(len(formatted)))
+		return append(out, formatted...)
+	default:
+		return []byte("ASSERT\n" + formatted + "\n")
+	}
+}
+
+// writeRecord writes one failure in a single write, keeping it in one piece for rotating
+// and async writers. Callers must hold flushLock.
+func (a *AssertHandler) writeRecord(w io.Writer, formatted string) {
+	w.Write(a.framing.record(formatted))
+}
+
+// writeRecords writes processed deferred failures. Callers must hold flushLock.
+func (a *AssertHandler) writeRecords(w io.Writer, formatted []string) {
+	if a.framing == FramingBlock {
+		fmt.Fprintln(w, strings.Join(formatted, "\n---\n"))
+		return
+	}
+	for _, f := range formatted {
+		a.writeRecord(w, f)
+	}
+}
+
+// flushAfterEvent flushes the writers in FlushEveryEvent mode. Callers must hold flushLock.
+func (a *AssertHandler) flushAfterEvent(ctx context.Context) {
+	if a.flushMode == FlushEveryEvent {
+		ctx, cancel := a.flushContext(ctx)
+		defer cancel()
+		a.flushWriters(ctx)
+	}
+}
@@ -0,0 +1,82 @@
+package assert
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRecordFraming(t *testing.T) {
+	formatter := &JSONFormatter{Compact: true}
+
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFormatter(formatter),
+		WithRecordFraming(FramingNewline))
+	handler.Assert(context.TODO(), false, "First")
+	handler.Assert(context.TODO(), false, "Second")
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"assertData":{"msg":"First"`) {
+		t.Fatalf("Expected one line per failure, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler = NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithRecordFraming(FramingNewline))
+	handler.Assert(context.TODO(), false, "Text")
+	if strings.Count(buffer.String(), "\n") != 1 || !strings.Contains(buffer.String(), `msg=Text\n`) {
+		t.Fatalf("Expected the text output escaped onto one line, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler = NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFormatter(formatter),
+		WithRecordFraming(FramingNUL))
+	handler.Assert(context.TODO(), false, "First")
+	handler.Assert(context.TODO(), false, "Second")
+	records := strings.Split(buffer.String(), "\x00")
+	if len(records) != 3 || records[2] != "" || !strings.Contains(records[1], "Second") {
+		t.Fatalf("Expected NUL terminated records, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler = NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFormatter(formatter),
+		WithRecordFraming(FramingLengthPrefix), WithDeferMode(true))
+	handler.Assert(context.TODO(), false, "First")
+	handler.Assert(context.TODO(), false, "Second")
+	buffer.Reset()
+	handler.ProcessDeferredAssertions(context.TODO())
+	for _, want := range []string{"First", "Second"} {
+		n := binary.BigEndian.Uint32(buffer.Next(4))
+		if record := string(buffer.Next(int(n))); !strings.Contains(record, want) || !strings.HasSuffix(record, "}") {
+			t.Fatalf("Expected a length-prefixed record for %s, got %q", want, record)
+		}
+	}
+	if buffer.Len() != 0 {
+		t.Fatalf("Unexpected trailing output %q", buffer.String())
+	}
+}
+
+func TestFlushMode(t *testing.T) {
+	// warnings don't exit, so nothing flushes the writer on the exit path
+	warn := WithErrorSeverityMapper(func(error) Severity { return SeverityWarn })
+	var buffer bytes.Buffer
+	buffered := bufio.NewWriterSize(&buffer, 1<<16)
+	handler := NewAssertHandler(WithWriter(buffered), warn)
+	handler.NoError(context.TODO(), os.ErrClosed, "Buffered")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected buffered output to stay in the buffer, got %q", buffer.String())
+	}
+	handler.Shutdown(context.TODO())
+	if !strings.Contains(buffer.String(), "Buffered") {
+		t.Fatalf("Expected Shutdown to flush the writer, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler = NewAssertHandler(WithWriter(buffered), warn, WithFlushMode(FlushEveryEvent))
+	handler.NoError(context.TODO(), os.ErrClosed, "Flushed")
+	if !strings.Contains(buffer.String(), "Flushed") {
+		t.Fatalf("Expected the failure to be flushed immediately, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,77 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// EqualRecords fails when got does not hold the same records as want, ignoring order.
+// Records are matched by keyFunc, so each missing, extra, duplicate or mismatched record,
+// and each key repeated in want, is listed individually instead of reporting two unequal
+// slices. It reports through the handler on ctx or the default handler.
+func EqualRecords[T any, K comparable](ctx context.Context, want, got []T, keyFunc func(T) K, msg string, data ...any) {
+	ok, data := equalRecords(want, got, keyFunc, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+func equalRecords[T any, K comparable](want, got []T, keyFunc func(T) K, data []any) (bool, []any) {
+	var missing, extra, duplicate, duplicateWant []K
+	wanted := make(map[K]T, len(want))
+	for _, w := range want {
+		key := keyFunc(w)
+		if _, ok := wanted[key]; ok {
+			duplicateWant = append(duplicateWant, key)
+			continue
+		}
+		wanted[key] = w
+	}
+
+	var mismatched []string
+	seen := make(map[K]bool, len(got))
+	for _, g := range got {
+		key := keyFunc(g)
+		if seen[key] {
+			duplicate = append(duplicate, key)
+			continue
+		}
+		seen[key] = true
+
+		w, ok := wanted[key]
+		if !ok {
+			extra = append(extra, key)
+			continue
+		}
+		if !reflect.DeepEqual(w, g) {
+			mismatched = append(mismatched, fmt.Sprintf("%v: want %+v, got %+v", key, w, g))
+		}
+	}
+	for _, w := range want {
+		if key := keyFunc(w); !seen[key] {
+			missing = append(missing, key)
+			seen[key] = true
+		}
+	}
+
+	if len(missing)+len(extra)+len(duplicate)+len(duplicateWant)+len(mismatched) == 0 {
+		return true, data
+	}
+
+	data = append(data, "want_count", len(want), "got_count", len(got))
+	if len(missing) > 0 {
+		data = append(data, "missing", missing)
+	}
+	if len(extra) > 0 {
+		data = append(data, "extra", extra)
+	}
+	if len(duplicate) > 0 {
+		data = append(data, "duplicate", duplicate)
+	}
+	if len(duplicateWant) > 0 {
+		data = append(data, "duplicate_want", duplicateWant)
+	}
+	if len(mismatched) > 0 {
+		data = append(data, "mismatched", mismatched)
+	}
+	return false, data
+}
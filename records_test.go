@@ -0,0 +1,51 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type row struct {
+	ID   int
+	Name string
+}
+
+func TestEqualRecords(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewIsolatedHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+	byID := func(r row) int { return r.ID }
+
+	want := []row{{1, "ada"}, {2, "bob"}, {3, "cy"}}
+	EqualRecords(ctx, want, []row{{3, "cy"}, {1, "ada"}, {2, "bob"}}, byID, "Rows Differ")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected reordered records to match, got %q", buffer.String())
+	}
+
+	EqualRecords(ctx, want, []row{{2, "bobby"}, {1, "ada"}, {4, "dee"}, {4, "dee"}}, byID, "Rows Differ")
+	output := buffer.String()
+	for _, expected := range []string{
+		"msg=Rows Differ",
+		"missing=[3]",
+		"extra=[4]",
+		"duplicate=[4]",
+		"mismatched=[2: want {ID:2 Name:bob}, got {ID:2 Name:bobby}]",
+	} {
+		if !bytes.Contains([]byte(output), []byte(expected)) {
+			t.Fatalf("Expected %q in output, got %s", expected, output)
+		}
+	}
+}
+
+func TestEqualRecordsDuplicateWant(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewIsolatedHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+	byID := func(r row) int { return r.ID }
+
+	EqualRecords(ctx, []row{{1, "a"}, {1, "b"}}, []row{{1, "a"}}, byID, "Rows Differ")
+	if output := buffer.String(); !bytes.Contains([]byte(output), []byte("duplicate_want=[1]")) || !bytes.Contains([]byte(output), []byte("want_count=2")) {
+		t.Fatalf("Expected the key repeated in want to be reported, got %q", output)
+	}
+}
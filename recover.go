@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// KindPanic classifies the failures reported for recovered panics. Its action decides what
+// happens after the report: ActionExit (the default) exits like any fatal failure, or defers
+// the failure in deferred mode and scopes, ActionPanic panics with the *AssertionError and
+// ActionLog continues after the deferred Recover call.
+const KindPanic Kind = "panic"
+
+// panicReporter is implemented by Asserters that report recovered panics
+type panicReporter interface {
+	ReportPanic(ctx context.Context, value any, stack []byte)
+}
+
+// Recover reports a panic of the calling goroutine as an assertion failure carrying the panic
+// value and the stack of the panic. It must be deferred directly:
+//
+//	defer assert.Recover(ctx, nil)
+//
+// A nil handler uses the handler on ctx or the default handler. Panics raised by the
+// assertion pipeline itself, such as those of WithExitPanic, and http.ErrAbortHandler,
+// which aborts a response on purpose, are passed on unchanged.
+func Recover(ctx context.Context, handler Asserter) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	switch r.(type) {
+	case exitPanic, *AssertionError:
+		panic(r)
+	}
+	if r == http.ErrAbortHandler {
+		panic(r)
+	}
+
+	if handler == nil {
+		handler = handlerFor(ctx)
+	}
+	stack := debug.Stack()
+	if p, ok := handler.(panicReporter); ok {
+		p.ReportPanic(ctx, r, stack)
+		return
+	}
+	handler.Never(ctx, "Recovered Panic", panicData(r, stack)...)
+}
+
+// Go runs fn in a new goroutine, reporting its panics through Recover with the handler on
+// ctx or the default handler
+func Go(ctx context.Context, fn func(ctx context.Context)) {
+	go func() {
+		defer Recover(ctx, nil)
+		fn(ctx)
+	}()
+}
+
+// ReportPanic reports a recovered panic value with the stack of the panic. Flushes, writers,
+// exporters and failure hooks run as for any failure; KindPanic's action decides what follows.
+func (a *AssertHandler) ReportPanic(ctx context.Context, value any, stack []byte) {
+	a.report(ctx, failure{severity: SeverityError, kind: KindPanic, msg: "Recovered Panic", args: panicData(value, stack)})
+}
+
+func panicData(value any, stack []byte) []any {
+	data := []any{"panic", value, "panic_type", fmt.Sprintf("%T", value), "panic_stack", string(stack)}
+	// errors.Is and errors.As reach a panicked error through the AssertionError
+	if err, ok := value.(error); ok {
+		data = append(data, "error", err)
+	}
+	return data
+}
@@ -0,0 +1,93 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+
+	func() {
+		defer Recover(context.TODO(), handler)
+		panicking()
+	}()
+
+	if exits != 1 {
+		t.Fatalf("Expected a recovered panic to exit, got %d exits", exits)
+	}
+	for _, want := range []string{"msg=Recovered Panic", "panic=boom", "panic_type=string", "assert-lib.panicking"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the output, got %q", want, buffer.String())
+		}
+	}
+}
+
+func panicking() {
+	panic("boom")
+}
+
+func TestRecoverKindActions(t *testing.T) {
+	var buffer bytes.Buffer
+	cause := errors.New("broken")
+
+	handler := NewAssertHandler(WithWriter(&buffer), WithKindAction(KindPanic, ActionPanic))
+	var err *AssertionError
+	func() {
+		defer func() { err, _ = recover().(*AssertionError) }()
+		defer Recover(context.TODO(), handler)
+		panic(cause)
+	}()
+	if err == nil || !errors.Is(err, cause) {
+		t.Fatalf("Expected an AssertionError wrapping the panic, got %v", err)
+	}
+
+	handler = NewAssertHandler(WithWriter(&buffer), WithKindAction(KindPanic, ActionLog))
+	done := make(chan struct{})
+	Go(NewContext(context.TODO(), handler), func(ctx context.Context) {
+		defer close(done)
+		defer Recover(ctx, nil)
+		panic("logged")
+	})
+	<-done
+
+	handler = NewAssertHandler(WithWriter(&buffer), WithExitPanic())
+	isolated := RunIsolated(func() {
+		defer Recover(context.TODO(), handler)
+		handler.Assert(context.TODO(), false, "Fatal Inside")
+	})
+	if isolated == nil || isolated.Msg != "Fatal Inside" {
+		t.Fatalf("Expected the exit sentinel to pass through Recover, got %v", isolated)
+	}
+
+	var aborted any
+	func() {
+		defer func() { aborted = recover() }()
+		defer Recover(context.TODO(), handler)
+		panic(http.ErrAbortHandler)
+	}()
+	if aborted != http.ErrAbortHandler {
+		t.Fatalf("Expected http.ErrAbortHandler to pass through Recover, got %v", aborted)
+	}
+}
+
+func TestGo(t *testing.T) {
+	var buffer bytes.Buffer
+	done := make(chan struct{})
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { close(done) }))
+
+	Go(NewContext(context.TODO(), handler), func(ctx context.Context) {
+		panic("in goroutine")
+	})
+	<-done
+
+	if !strings.Contains(buffer.String(), "panic=in goroutine") {
+		t.Fatalf("Expected the goroutine panic to be reported, got %q", buffer.String())
+	}
+}
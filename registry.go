@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CheckFunc is a named assertion registered with Register. It reports whether args satisfy
+// the rule and the data describing a violation.
+type CheckFunc func(args ...any) (bool, map[string]any)
+
+var (
+	checksMu sync.RWMutex
+	checks   = map[string]CheckFunc{}
+)
+
+// Register makes fn available to Check under name, typically from an init function of the
+// package owning the domain rule. It panics if name is already registered or fn is nil.
+func Register(name string, fn func(args ...any) (bool, map[string]any)) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	if fn == nil {
+		panic("assert: Register check is nil")
+	}
+	if _, dup := checks[name]; dup {
+		panic("assert: Register called twice for check " + name)
+	}
+	checks[name] = fn
+}
+
+// Check runs the check registered under name with args and fails with the message
+// "Check Failed: <name>" when it is violated, adding the rule name and the data the check
+// returned. Checking an unregistered name fails as well. It reports whether the check passed.
+func (a *AssertHandler) Check(ctx context.Context, name string, args ...any) bool {
+	ok, data := runCheck(name, args)
+	a.evaluate(ctx, KindAssert, ok, "Check Failed: "+name, data)
+	return ok
+}
+
+// Check runs the check registered under name through the default handler
+func Check(ctx context.Context, name string, args ...any) bool {
+	ok, data := runCheck(name, args)
+	handlerFor(ctx).Assert(ctx, ok, "Check Failed: "+name, data...)
+	return ok
+}
+
+func runCheck(name string, args []any) (bool, []any) {
+	checksMu.RLock()
+	fn, registered := checks[name]
+	checksMu.RUnlock()
+	if !registered {
+		return false, []any{"rule", name, "error", fmt.Sprintf("no check registered as %q", name)}
+	}
+
+	ok, result := fn(args...)
+	if ok {
+		return true, nil
+	}
+	keys := make([]string, 0, len(result))
+	for k := range result {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	data := []any{"rule", name}
+	for _, k := range keys {
+		data = append(data, k, result[k])
+	}
+	return false, data
+}
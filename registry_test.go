@@ -0,0 +1,51 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRegisterAndCheck(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+
+	t.Cleanup(func() {
+		checksMu.Lock()
+		delete(checks, "validOrderState")
+		checksMu.Unlock()
+	})
+	Register("validOrderState", func(args ...any) (bool, map[string]any) {
+		from, to := args[0].(string), args[1].(string)
+		if from == "shipped" && to == "pending" {
+			return false, map[string]any{"from": from, "to": to}
+		}
+		return true, nil
+	})
+
+	if !Check(ctx, "validOrderState", "pending", "shipped") || buffer.Len() != 0 {
+		t.Fatalf("Expected the check to pass, got %q", buffer.String())
+	}
+	if handler.Check(ctx, "validOrderState", "shipped", "pending") {
+		t.Fatalf("Expected the check to fail")
+	}
+	for _, want := range []string{"msg=Check Failed: validOrderState", "rule=validOrderState", "from=shipped", "to=pending"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the failure, got %q", want, buffer.String())
+		}
+	}
+
+	buffer.Reset()
+	if Check(ctx, "unknownRule") || !strings.Contains(buffer.String(), `no check registered as "unknownRule"`) {
+		t.Fatalf("Expected an unregistered check to fail, got %q", buffer.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected registering a name twice to panic")
+		}
+	}()
+	Register("validOrderState", func(args ...any) (bool, map[string]any) { return true, nil })
+}
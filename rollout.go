@@ -0,0 +1,59 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// Rollout ramps up enforcement of the failures with one code. Percent of evaluations are
+// enforced as usual; the others are only recorded: they are written and exported but never
+// defer, panic or exit.
+type Rollout struct {
+	// Percent of evaluations that are enforced, from 0 to 100
+	Percent float64
+	// Key returns the value bucketing an evaluation, such as the user ID, so the same user
+	// consistently lands on the same side. Evaluations without a key are bucketed randomly.
+	Key func(ctx context.Context) string
+}
+
+// WithRollout enforces the failures reported with the given code for a percentage of
+// evaluations only, so new fatal invariants can be ramped up gradually in production
+func WithRollout(code string, rollout Rollout) Option {
+	return func(a *AssertHandler) {
+		if a.rollouts == nil {
+			a.rollouts = make(map[string]Rollout)
+		}
+		a.rollouts[code] = rollout
+	}
+}
+
+// enforced reports whether a failure with the given data is enforced under its code's
+// rollout. Callers must hold flushLock.
+func (a *AssertHandler) enforced(ctx context.Context, data map[string]interface{}) bool {
+	code, ok := data["code"]
+	if !ok {
+		return true
+	}
+	rollout, ok := a.rollouts[fmt.Sprint(code)]
+	if !ok {
+		return true
+	}
+
+	key := ""
+	if rollout.Key != nil {
+		key = rollout.Key(ctx)
+	}
+	return rolloutBucket(fmt.Sprint(code), key) < rollout.Percent
+}
+
+// rolloutBucket maps code and key to a stable value in [0, 100), or a random one without key
+func rolloutBucket(code, key string) float64 {
+	if key == "" {
+		return rand.Float64() * 100
+	}
+	h := fnv.New32a()
+	h.Write([]byte(code + ":" + key))
+	return float64(h.Sum32()%10000) / 100
+}
@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type userKey struct{}
+
+func TestRollout(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	byUser := func(ctx context.Context) string {
+		user, _ := ctx.Value(userKey{}).(string)
+		return user
+	}
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }),
+		WithRollout("NEW-INV", Rollout{Percent: 25, Key: byUser}),
+		WithRollout("OFF", Rollout{Percent: 0}))
+
+	enforcedUsers := 0
+	for i := 0; i < 400; i++ {
+		ctx := context.WithValue(context.TODO(), userKey{}, fmt.Sprintf("user-%d", i))
+		before := exits
+		handler.Assert(ctx, false, "New Invariant", "code", "NEW-INV")
+		handler.Assert(ctx, false, "New Invariant", "code", "NEW-INV")
+		switch exits - before {
+		case 2:
+			enforcedUsers++
+		case 1:
+			t.Fatalf("Expected the same user to land on the same side of the rollout")
+		}
+	}
+	if enforcedUsers < 60 || enforcedUsers > 140 {
+		t.Fatalf("Expected roughly a quarter of users to be enforced, got %d of 400", enforcedUsers)
+	}
+
+	buffer.Reset()
+	exits = 0
+	handler.Assert(context.TODO(), false, "Disabled Invariant", "code", "OFF")
+	handler.Assert(context.TODO(), false, "Other Failure", "code", "OTHER")
+	if exits != 1 || !strings.Contains(buffer.String(), "enforcement=record-only") {
+		t.Fatalf("Expected the 0%% rollout to only record, got %d exits and %q", exits, buffer.String())
+	}
+}
@@ -0,0 +1,50 @@
+package assert
+
+// KindValidation classifies the failures of the validators, such as ValidStruct and
+// ValidEmail, so they can be routed apart from invariants
+const KindValidation Kind = "validation"
+
+// WithRoute runs policy instead of the handler's failure policy after fatal failures of kind
+func WithRoute(kind Kind, policy FailurePolicy) Option {
+	return func(a *AssertHandler) {
+		a.routes[kind] = policy
+	}
+}
+
+// WithSeverityRoute runs policy after fatal failures of severity that no kind route matches
+func WithSeverityRoute(severity Severity, policy FailurePolicy) Option {
+	return func(a *AssertHandler) {
+		a.severityRoutes[severity] = policy
+	}
+}
+
+// Route runs policy instead of the handler's failure policy after fatal failures of kind,
+// e.g. keeping validation soft while invariants crash:
+//
+//	handler.Route(assert.KindValidation, assert.LogOnlyPolicy{})
+//	handler.Route(assert.KindInvariant, assert.PanicPolicy{})
+//
+// Processed deferred failures are routed by their kind when they all share one.
+func (a *AssertHandler) Route(kind Kind, policy FailurePolicy) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	a.routes[kind] = policy
+}
+
+// RouteSeverity runs policy after fatal failures of severity that no kind route matches
+func (a *AssertHandler) RouteSeverity(severity Severity, policy FailurePolicy) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	a.severityRoutes[severity] = policy
+}
+
+// failurePolicyFor returns the policy event is routed to. Callers must hold flushLock.
+func (a *AssertHandler) failurePolicyFor(event AssertionEvent) FailurePolicy {
+	if policy, ok := a.routes[event.Kind]; ok {
+		return policy
+	}
+	if policy, ok := a.severityRoutes[event.Severity]; ok {
+		return policy
+	}
+	return a.failurePolicy
+}
@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestRouteByKind(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+	handler.Route(KindValidation, LogOnlyPolicy{})
+	handler.Route(KindInvariant, PanicPolicy{})
+
+	type request struct {
+		Name string `assert:"nonzero"`
+		Age  int    `assert:"min=18"`
+	}
+	handler.ValidStruct(context.TODO(), request{Age: 3}, "Invalid Request")
+	ValidEmail(NewContext(context.TODO(), handler), "not an email", "Invalid Email")
+	if exits != 0 {
+		t.Fatalf("Expected validation failures to be routed to the log-only policy, got %d exits", exits)
+	}
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		handler.Invariant(context.TODO(), false, "Ledger Balanced")
+	}()
+	if err, ok := recovered.(*AssertionError); !ok || err.Msg != "Ledger Balanced" {
+		t.Fatalf("Expected the invariant to be routed to the panic policy, got %v", recovered)
+	}
+
+	handler.Assert(context.TODO(), false, "Unrouted")
+	if exits != 1 {
+		t.Fatalf("Expected other kinds to use the failure policy, got %d exits", exits)
+	}
+}
+
+func TestRouteBySeverity(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	errs := &ErrorPolicy{}
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) { exits++ }),
+		WithSeverityRoute(SeverityError, errs),
+		WithRoute(KindPrecondition, ExitPolicy{Exit: func(code int) { exits++ }}),
+	)
+
+	handler.Assert(context.TODO(), false, "Soft Error")
+	if exits != 0 || errs.Err() == nil {
+		t.Fatalf("Expected the error to be routed by its severity")
+	}
+	handler.Require(context.TODO(), false, "Input Valid")
+	if exits != 1 {
+		t.Fatalf("Expected a kind route to take precedence over a severity route")
+	}
+}
@@ -0,0 +1,224 @@
+package assert
+
+import (
+	_ "embed"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//go:embed schema/event.schema.json
+var eventSchema []byte
+
+// SchemaVersion is the version of the language-neutral event schema
+const SchemaVersion = "1"
+
+// EventSchema returns the JSON Schema of the events written by SchemaFormatter, also
+// published as schema/event.schema.json for consumers in other languages
+func EventSchema() []byte {
+	return append([]byte{}, eventSchema...)
+}
+
+// WithStrictSchema formats failures with SchemaFormatter, so events of Go services can be
+// processed alongside those of other languages
+func WithStrictSchema() Option {
+	return WithEventFormatter(&SchemaFormatter{})
+}
+
+// SchemaEvent is an event in the language-neutral schema
+type SchemaEvent struct {
+	SchemaVersion string         `json:"schema_version"`
+	Time          string         `json:"time"`
+	Severity      string         `json:"severity"`
+	Message       string         `json:"message"`
+	Code          string         `json:"code,omitempty"`
+	Fingerprint   string         `json:"fingerprint"`
+	Caller        string         `json:"caller,omitempty"`
+	Data          map[string]any `json:"data"`
+	Stack         string         `json:"stack,omitempty"`
+	Frames        []Frame        `json:"frames,omitempty"`
+}
+
+// schemaTopLevel are the data keys promoted to fields of SchemaEvent
+var schemaTopLevel = map[string]bool{"msg": true, "severity": true, "caller": true, "code": true, fingerprintKey: true}
+
+// NewSchemaEvent converts event to the language-neutral schema. Data keys become snake_case,
+// times RFC 3339 strings in UTC, durations seconds, errors and Stringers their text, and
+// integers beyond 2^53 strings; values JSON cannot represent become their %v text.
+func NewSchemaEvent(event AssertionEvent) SchemaEvent {
+	s := SchemaEvent{
+		SchemaVersion: SchemaVersion,
+		Time:          event.Time.UTC().Format(time.RFC3339Nano),
+		Severity:      strings.ToLower(event.Severity.String()),
+		Message:       event.Message,
+		Fingerprint:   event.Fingerprint,
+		Caller:        event.Caller,
+		Data:          make(map[string]any, len(event.Data)),
+		Stack:         event.Stack,
+	}
+	if code, ok := event.Data["code"]; ok {
+		s.Code = fmt.Sprint(code)
+	}
+	for k, v := range event.Data {
+		if !schemaTopLevel[k] {
+			s.Data[schemaKey(k)] = schemaValue(reflect.ValueOf(v), 0)
+		}
+	}
+	s.Frames = framesOf(event.Frames)
+	return s
+}
+
+// SchemaFormatter writes events as single-line JSON following EventSchema
+type SchemaFormatter struct{}
+
+func (f *SchemaFormatter) FormatEvent(event AssertionEvent) ([]byte, error) {
+	return json.Marshal(NewSchemaEvent(event))
+}
+
+// schemaKey converts a key to snake_case, keeping dots of namespaced keys
+func schemaKey(key string) string {
+	var b strings.Builder
+	runes := []rune(key)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			// a new word starts at an upper case letter after a lower case one, or at the
+			// last upper case letter of an acronym followed by a lower case one
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case r < unicode.MaxASCII && (unicode.IsLower(r) || unicode.IsDigit(r) || r == '_' || r == '.'):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+const (
+	// maxSafeInteger is the largest integer every JSON parser reads exactly
+	maxSafeInteger = 1<<53 - 1
+	// maxSchemaDepth bounds the conversion of nested and cyclic values
+	maxSchemaDepth = 16
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textType     = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaValue converts v to plain JSON values following the schema's coercions
+func schemaValue(v reflect.Value, depth int) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth > maxSchemaDepth {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).UTC().Format(time.RFC3339Nano)
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).Seconds()
+	case v.Type().Implements(errorType):
+		return v.Interface().(error).Error()
+	case v.Type().Implements(textType):
+		if text, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(text)
+		}
+	case v.Type().Implements(stringerType):
+		return v.Interface().(fmt.Stringer).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return schemaValue(v.Elem(), depth+1)
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if n > maxSafeInteger || n < -maxSafeInteger {
+			return fmt.Sprint(n)
+		}
+		return n
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := v.Uint()
+		if n > maxSafeInteger {
+			return fmt.Sprint(n)
+		}
+		return n
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Sprint(f)
+		}
+		return f
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			// bytes are text more often than not; other bytes are shown escaped
+			return fmt.Sprintf("%s", v.Bytes())
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = schemaValue(v.Index(i), depth+1)
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[schemaKey(fmt.Sprint(iter.Key().Interface()))] = schemaValue(iter.Value(), depth+1)
+		}
+		return out
+	case reflect.Struct:
+		return schemaStruct(v, depth)
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// schemaStruct converts the exported fields of a struct, named by their json tag if any
+func schemaStruct(v reflect.Value, depth int) map[string]any {
+	out := map[string]any{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		out[schemaKey(name)] = schemaValue(v.Field(i), depth+1)
+	}
+	return out
+}
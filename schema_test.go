@@ -0,0 +1,113 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestSchemaFormatter(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithStrictSchema(), WithRecordFraming(FramingNewline))
+
+	type order struct {
+		OrderID  int64
+		Customer string `json:"customerName"`
+		internal string
+	}
+	handler.Assert(context.TODO(), false, "Order Invalid",
+		"code", "orders.invalid",
+		"userID", 42,
+		"HTTPStatus", 502,
+		"timeout", 1500*time.Millisecond,
+		"deadline", time.Date(2024, 5, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*3600)),
+		"err", errors.New("boom"),
+		"large", int64(1)<<60,
+		"order", order{OrderID: 7, Customer: "ada", internal: "x"},
+	)
+
+	var event map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(buffer.Bytes()), &event); err != nil {
+		t.Fatalf("Expected a single JSON document, got %q: %v", buffer.String(), err)
+	}
+	if event["schema_version"] != SchemaVersion || event["severity"] != "error" || event["message"] != "Order Invalid" || event["code"] != "orders.invalid" {
+		t.Fatalf("Expected the top-level fields, got %v", event)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, event["time"].(string)); err != nil {
+		t.Fatalf("Expected an RFC 3339 time, got %v", event["time"])
+	}
+
+	data := event["data"].(map[string]any)
+	want := map[string]any{
+		"user_id":     float64(42),
+		"http_status": float64(502),
+		"timeout":     1.5,
+		"deadline":    "2024-05-01T12:00:00Z",
+		"err":         "boom",
+		"large":       "1152921504606846976",
+		"order":       map[string]any{"order_id": float64(7), "customer_name": "ada"},
+	}
+	for k, v := range want {
+		got, _ := json.Marshal(data[k])
+		expected, _ := json.Marshal(v)
+		if !bytes.Equal(got, expected) {
+			t.Fatalf("Expected data %q to be %s, got %s", k, expected, got)
+		}
+	}
+	for _, k := range []string{"msg", "code", "caller", "fingerprint", "severity"} {
+		if _, ok := data[k]; ok {
+			t.Fatalf("Expected %q to be promoted out of data, got %v", k, data)
+		}
+	}
+}
+
+func TestEventSchemaMatchesSchemaEvent(t *testing.T) {
+	var schema struct {
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(EventSchema(), &schema); err != nil {
+		t.Fatalf("Expected the embedded schema to be JSON: %v", err)
+	}
+
+	encoded, _ := json.Marshal(NewSchemaEvent(AssertionEvent{
+		Frames: callerFrames(),
+		Stack:  "stack",
+		Data:   map[string]interface{}{"code": "c", "caller": "x.go:1"},
+	}))
+	var fields map[string]json.RawMessage
+	json.Unmarshal(encoded, &fields)
+	for name := range fields {
+		if _, ok := schema.Properties[name]; !ok {
+			t.Fatalf("Expected field %q to be described by the schema", name)
+		}
+	}
+	for _, name := range schema.Required {
+		if _, ok := fields[name]; !ok {
+			t.Fatalf("Expected required field %q in every event", name)
+		}
+	}
+
+	var data struct {
+		PropertyNames struct {
+			Pattern string `json:"pattern"`
+		} `json:"propertyNames"`
+	}
+	json.Unmarshal(schema.Properties["data"], &data)
+	pattern := regexp.MustCompile(data.PropertyNames.Pattern)
+	for _, key := range []string{"userID", "HTTPServer", "db.poolSize", "retry-count", "Straße"} {
+		if converted := schemaKey(key); !pattern.MatchString(converted) {
+			t.Fatalf("Expected %q to convert to a valid key, got %q", key, converted)
+		}
+	}
+	if got := schemaKey("db.poolSize"); got != "db.pool_size" {
+		t.Fatalf("Expected namespaced keys to keep their dots, got %q", got)
+	}
+	if got := schemaKey("HTTPServer"); got != "http_server" {
+		t.Fatalf("Expected acronyms to form one word, got %q", got)
+	}
+}
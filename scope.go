@@ -0,0 +1,25 @@
+package assert
+
+import "context"
+
+// deferredScopeKey identifies a deferred scope of one handler family on a context
+type deferredScopeKey struct {
+	store *deferredStore
+}
+
+// BeginDeferredScope returns a context in which failures reported by a (and handlers derived
+// from it) are collected in a buffer private to that context instead of failing immediately
+// or joining the handler-wide deferred failures. Calling done processes the scope's failures
+// like ProcessDeferredAssertions. Concurrent requests can each validate in their own scope.
+func (a *AssertHandler) BeginDeferredScope(ctx context.Context) (context.Context, func()) {
+	scope := &deferredStore{}
+	ctx = context.WithValue(ctx, deferredScopeKey{store: a.deferred}, scope)
+	return ctx, func() {
+		a.processDeferred(ctx, scope.drain())
+	}
+}
+
+func deferredScopeFrom(ctx context.Context, store *deferredStore) *deferredStore {
+	scope, _ := ctx.Value(deferredScopeKey{store: store}).(*deferredStore)
+	return scope
+}
@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestDeferredScopesAreIndependent(t *testing.T) {
+	var buffer bytes.Buffer
+	var mu sync.Mutex
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {
+		mu.Lock()
+		exits++
+		mu.Unlock()
+	}))
+
+	ctxA, doneA := handler.BeginDeferredScope(context.TODO())
+	ctxB, doneB := handler.BeginDeferredScope(context.TODO())
+
+	handler.Assert(ctxA, false, "Request A Invalid")
+	handler.WithTags("child", true).Assert(ctxA, false, "Request A Child Invalid")
+	if exits != 0 {
+		t.Fatalf("Expected scoped failures to be collected")
+	}
+
+	buffer.Reset()
+	doneB()
+	if exits != 0 || buffer.Len() != 0 {
+		t.Fatalf("Expected scope B to be unaffected by scope A")
+	}
+
+	doneA()
+	if exits != 1 {
+		t.Fatalf("Expected processing scope A to exit once, got %d", exits)
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("Request A Invalid")) || !bytes.Contains(buffer.Bytes(), []byte("Request A Child Invalid")) {
+		t.Fatalf("Expected scope A failures in output")
+	}
+
+	handler.Assert(ctxB, false, "Handler Wide")
+	handler.ProcessDeferredAssertions(context.TODO())
+	if exits != 1 {
+		t.Fatalf("Expected scope failures to stay out of the handler-wide buffer")
+	}
+}
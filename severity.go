@@ -0,0 +1,51 @@
+package assert
+
+// Severity ranks how serious a failed assertion is.
+// Error and Fatal failures are deferred or exit; Info and Warn failures are only reported.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarn
+	SeverityError
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarn:
+		return "WARN"
+	case SeverityError:
+		return "ERROR"
+	case SeverityFatal:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// exits reports whether a failure of this severity triggers the exit path
+func (s Severity) exits() bool {
+	return s >= SeverityError
+}
+
+// WithErrorSeverityMapper sets how error-checking assertions such as NoError derive the
+// severity of a failure from the error value, e.g. mapping context.Canceled to SeverityWarn
+func WithErrorSeverityMapper(mapper func(error) Severity) Option {
+	return func(a *AssertHandler) {
+		a.errorSeverity = mapper
+	}
+}
+
+func (a *AssertHandler) severityForError(err error) Severity {
+	a.flushLock.Lock()
+	mapper := a.errorSeverity
+	a.flushLock.Unlock()
+
+	if mapper == nil {
+		return SeverityError
+	}
+	return mapper(err)
+}
@@ -0,0 +1,39 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestErrorSeverityMapper(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) { exits++ }),
+		WithErrorSeverityMapper(func(err error) Severity {
+			if errors.Is(err, context.Canceled) {
+				return SeverityWarn
+			}
+			return SeverityFatal
+		}),
+	)
+
+	handler.NoError(context.TODO(), context.Canceled, "Request Canceled")
+	if exits != 0 {
+		t.Fatalf("Expected WARN failure not to exit")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("severity=WARN")) {
+		t.Fatalf("Expected WARN severity in output")
+	}
+
+	handler.NoError(context.TODO(), errors.New("checksum mismatch"), "Data Corruption")
+	if exits != 1 {
+		t.Fatalf("Expected FATAL failure to exit")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("severity=FATAL")) {
+		t.Fatalf("Expected FATAL severity in output")
+	}
+}
@@ -0,0 +1,66 @@
+package sinktest
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+)
+
+// ErrInjected is the default error returned by Chaos for injected failures
+var ErrInjected = errors.New("sinktest: injected failure")
+
+// Chaos wraps an Exporter and injects latency and failures into its Export calls
+type Chaos struct {
+	Next assert.Exporter
+	// Latency delays every Export call; the delay is cut short when the context is done
+	Latency time.Duration
+	// FailFirst fails the first FailFirst Export calls
+	FailFirst int
+	// FailureRate is the probability of failing any later Export call
+	FailureRate float64
+	// Err is returned for injected failures, ErrInjected when nil
+	Err error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *Chaos) Export(ctx context.Context, events []assert.AssertionEvent) error {
+	if c.Latency > 0 {
+		timer := time.NewTimer(c.Latency)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	c.mu.Lock()
+	c.calls++
+	fail := c.calls <= c.FailFirst || (c.FailureRate > 0 && rand.Float64() < c.FailureRate)
+	c.mu.Unlock()
+
+	if fail {
+		if c.Err != nil {
+			return c.Err
+		}
+		return ErrInjected
+	}
+	return c.Next.Export(ctx, events)
+}
+
+func (c *Chaos) Shutdown(ctx context.Context) error {
+	return c.Next.Shutdown(ctx)
+}
+
+// Calls returns how many Export calls were received, including failed ones
+func (c *Chaos) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
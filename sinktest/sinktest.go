@@ -0,0 +1,184 @@
+// Package sinktest exercises the delivery, retry, backpressure and shutdown semantics of
+// assert.Exporter implementations, and provides a Chaos wrapper for fault injection.
+//
+// The exporter under test is built by a Factory on top of a backend exporter recording what
+// is delivered. Exporters that talk to a network backend can be tested by pointing them at a
+// test server that forwards what it receives to that backend.
+package sinktest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+	"github.com/ZanzyTHEbar/assert-lib/fakes"
+)
+
+// Factory builds the exporter under test delivering to next
+type Factory func(next assert.Exporter) assert.Exporter
+
+// exportTimeout bounds a single Export call against a stalled backend
+const exportTimeout = 100 * time.Millisecond
+
+// Run runs every check of the kit
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+	t.Run("Delivery", func(t *testing.T) { Delivery(t, factory) })
+	t.Run("Retry", func(t *testing.T) { Retry(t, factory) })
+	t.Run("Backpressure", func(t *testing.T) { Backpressure(t, factory) })
+	t.Run("Shutdown", func(t *testing.T) { Shutdown(t, factory) })
+	t.Run("Concurrent", func(t *testing.T) { Concurrent(t, factory) })
+}
+
+// Delivery checks that every event exported in batches of any size is delivered exactly
+// once by the time Shutdown returns
+func Delivery(t *testing.T, factory Factory) {
+	backend := &fakes.RecordingExporter{}
+	exporter := factory(backend)
+
+	n := 0
+	for _, size := range []int{1, 10, 14} {
+		if err := exporter.Export(context.Background(), Events(n, size)); err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		n += size
+	}
+	shutdown(t, exporter)
+	expectDelivered(t, backend, n)
+}
+
+// Retry checks that events survive transient backend failures
+func Retry(t *testing.T, factory Factory) {
+	backend := &fakes.RecordingExporter{}
+	chaos := &Chaos{Next: backend, FailFirst: 2}
+	exporter := factory(chaos)
+
+	if err := exporter.Export(context.Background(), Events(0, 5)); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	shutdown(t, exporter)
+	expectDelivered(t, backend, 5)
+	if chaos.Calls() < 3 {
+		t.Fatalf("expected the failed calls to be retried, got %d calls", chaos.Calls())
+	}
+}
+
+// Backpressure checks that Export does not block on a stalled backend and that Shutdown
+// honors its context deadline instead of waiting for the backend
+func Backpressure(t *testing.T, factory Factory) {
+	backend := &fakes.RecordingExporter{}
+	exporter := factory(&Chaos{Next: backend, Latency: time.Hour})
+
+	for i := 0; i < 200; i++ {
+		start := time.Now()
+		exporter.Export(context.Background(), Events(i, 1))
+		if elapsed := time.Since(start); elapsed > exportTimeout {
+			t.Fatalf("Export blocked for %v on a stalled backend", elapsed)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		exporter.Shutdown(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * exportTimeout):
+		t.Fatalf("Shutdown ignored its context deadline on a stalled backend")
+	}
+}
+
+// Shutdown checks that Shutdown shuts down the backend, can be called twice and that Export
+// after Shutdown neither panics nor delivers
+func Shutdown(t *testing.T, factory Factory) {
+	backend := &fakes.RecordingExporter{}
+	exporter := factory(backend)
+
+	shutdown(t, exporter)
+	if !backend.IsShutdown() {
+		t.Fatalf("expected Shutdown to shut down the backend")
+	}
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected a second Shutdown to succeed, got %v", err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("Export after Shutdown panicked: %v", r)
+			}
+		}()
+		exporter.Export(context.Background(), Events(0, 1))
+	}()
+	if len(backend.Events()) != 0 {
+		t.Fatalf("expected nothing to be delivered after Shutdown")
+	}
+}
+
+// Concurrent checks that concurrent exports are all delivered
+func Concurrent(t *testing.T, factory Factory) {
+	backend := &fakes.RecordingExporter{}
+	exporter := factory(backend)
+
+	const goroutines, perGoroutine = 8, 50
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				exporter.Export(context.Background(), Events(g*perGoroutine+i, 1))
+			}
+		}(g)
+	}
+	wg.Wait()
+	shutdown(t, exporter)
+	expectDelivered(t, backend, goroutines*perGoroutine)
+}
+
+// Events returns n events with the distinct messages "sinktest-<first>" onwards
+func Events(first, n int) []assert.AssertionEvent {
+	events := make([]assert.AssertionEvent, n)
+	for i := range events {
+		msg := fmt.Sprintf("sinktest-%d", first+i)
+		events[i] = assert.AssertionEvent{
+			Time:     time.Now(),
+			Severity: assert.SeverityError,
+			Message:  msg,
+			Data:     map[string]interface{}{"msg": msg},
+		}
+	}
+	return events
+}
+
+func shutdown(t *testing.T, exporter assert.Exporter) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := exporter.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+}
+
+func expectDelivered(t *testing.T, backend *fakes.RecordingExporter, n int) {
+	t.Helper()
+	seen := make(map[string]int)
+	for _, e := range backend.Events() {
+		seen[e.Message]++
+	}
+	for i := 0; i < n; i++ {
+		msg := fmt.Sprintf("sinktest-%d", i)
+		if seen[msg] != 1 {
+			t.Fatalf("expected %s to be delivered once, got %d", msg, seen[msg])
+		}
+	}
+	if len(seen) != n {
+		t.Fatalf("expected %d distinct events, got %d", n, len(seen))
+	}
+}
@@ -0,0 +1,84 @@
+package sinktest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ZanzyTHEbar/assert-lib"
+	"github.com/ZanzyTHEbar/assert-lib/fakes"
+)
+
+func TestBatcher(t *testing.T) {
+	Run(t, func(next assert.Exporter) assert.Exporter {
+		return assert.NewBatcher(next, assert.BatcherConfig{
+			MaxBatchSize:   10,
+			MaxDelay:       10 * time.Millisecond,
+			InitialBackoff: time.Millisecond,
+		})
+	})
+}
+
+func TestChaos(t *testing.T) {
+	backend := &fakes.RecordingExporter{}
+	chaos := &Chaos{Next: backend, FailFirst: 1, Latency: time.Millisecond}
+
+	if err := chaos.Export(context.Background(), Events(0, 1)); !errors.Is(err, ErrInjected) {
+		t.Fatalf("Expected the first call to fail, got %v", err)
+	}
+	if err := chaos.Export(context.Background(), Events(1, 1)); err != nil {
+		t.Fatalf("Expected the second call to pass, got %v", err)
+	}
+
+	always := &Chaos{Next: backend, FailureRate: 1}
+	if err := always.Export(context.Background(), Events(2, 1)); !errors.Is(err, ErrInjected) {
+		t.Fatalf("Expected a failure rate of 1 to always fail, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := &Chaos{Next: backend, Latency: time.Hour}
+	if err := slow.Export(ctx, Events(3, 1)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the latency to be cut short, got %v", err)
+	}
+	if len(backend.Events()) != 1 || chaos.Calls() != 2 {
+		t.Fatalf("Expected only the passing call to be delivered")
+	}
+}
+
+// forwardingWebhook is a webhook exporter posting to a test server that forwards every
+// received payload to next
+type forwardingWebhook struct {
+	*assert.Batcher
+	server *httptest.Server
+	next   assert.Exporter
+}
+
+func (f *forwardingWebhook) Shutdown(ctx context.Context) error {
+	defer f.server.Close()
+	return errors.Join(f.Batcher.Shutdown(ctx), f.next.Shutdown(ctx))
+}
+
+func TestWebhook(t *testing.T) {
+	Run(t, func(next assert.Exporter) assert.Exporter {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var p assert.WebhookPayload
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			event := assert.AssertionEvent{Time: p.Time, Message: p.Message, Data: p.Data}
+			if err := next.Export(r.Context(), []assert.AssertionEvent{event}); err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			}
+		}))
+		webhook := assert.NewWebhookExporter(server.URL, assert.WebhookConfig{
+			Batcher: assert.BatcherConfig{MaxDelay: 10 * time.Millisecond, InitialBackoff: time.Millisecond},
+		})
+		return &forwardingWebhook{Batcher: webhook, server: server, next: next}
+	})
+}
@@ -0,0 +1,104 @@
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+// Soft collects the failures of the checks run inside Softly. Its checks never exit; the
+// failures are reported together when the Softly function returns.
+type Soft struct {
+	ctx      context.Context
+	asserter Asserter
+}
+
+// Softly runs fn, collecting every failure of its checks, then reports them at once and
+// runs the exit path once according to the deferred policy, returning the aggregate error
+// if the process goes on. Unlike WithDeferMode it affects only the checks made through s or
+// with s.Context(), so unrelated call sites keep failing immediately.
+//
+//	err := h.Softly(ctx, func(s *assert.Soft) {
+//		s.Equal(want.Total, got.Total, "Total Mismatch")
+//		s.NotNil(got.Customer, "Customer Missing")
+//	})
+func (a *AssertHandler) Softly(ctx context.Context, fn func(s *Soft)) error {
+	scope := &deferredStore{}
+	scoped := context.WithValue(NewContext(ctx, a), deferredScopeKey{store: a.deferred}, scope)
+
+	// failures collected before a panic are still reported
+	defer func() {
+		if r := recover(); r != nil {
+			a.processDeferred(scoped, scope.drain())
+			panic(r)
+		}
+	}()
+	fn(&Soft{ctx: scoped, asserter: a})
+
+	return a.processDeferred(scoped, scope.drain())
+}
+
+// Softly runs fn collecting failures through the handler on ctx or the default handler.
+// Asserters other than *AssertHandler cannot collect, so their failures are reported as
+// they happen.
+func Softly(ctx context.Context, fn func(s *Soft)) error {
+	a := handlerFor(ctx)
+	if h, ok := a.(*AssertHandler); ok {
+		return h.Softly(ctx, fn)
+	}
+	fn(&Soft{ctx: ctx, asserter: a})
+	return nil
+}
+
+// Context returns the context of the soft scope: package-level assertions called with it
+// are collected like the checks of s
+func (s *Soft) Context() context.Context {
+	return s.ctx
+}
+
+// Assert records a failure when truth is false
+func (s *Soft) Assert(truth bool, msg string, data ...any) {
+	s.asserter.Assert(s.ctx, truth, msg, data...)
+}
+
+// AssertWithTimeout records a failure when truth is false, reporting under a context with timeout
+func (s *Soft) AssertWithTimeout(timeout time.Duration, truth bool, msg string, data ...any) {
+	s.asserter.AssertWithTimeout(s.ctx, timeout, truth, msg, data...)
+}
+
+// Nil records a failure when item is not nil
+func (s *Soft) Nil(item any, msg string, data ...any) {
+	s.asserter.Nil(s.ctx, item, msg, data...)
+}
+
+// NotNil records a failure when item is nil
+func (s *Soft) NotNil(item any, msg string, data ...any) {
+	s.asserter.NotNil(s.ctx, item, msg, data...)
+}
+
+// NoError records a failure when err is not nil
+func (s *Soft) NoError(err error, msg string, data ...any) {
+	s.asserter.NoError(s.ctx, err, msg, data...)
+}
+
+// Equal records a failure when expected and actual are not deeply equal
+func (s *Soft) Equal(expected, actual any, msg string, data ...any) {
+	ok, data := equal(expected, actual, data)
+	s.asserter.Assert(s.ctx, ok, msg, data...)
+}
+
+// Zero records a failure when v is not the zero value of its type
+func (s *Soft) Zero(v any, msg string, data ...any) {
+	ok, data := zero(v, true, data)
+	s.asserter.Assert(s.ctx, ok, msg, data...)
+}
+
+// NotZero records a failure when v is nil or the zero value of its type
+func (s *Soft) NotZero(v any, msg string, data ...any) {
+	ok, data := zero(v, false, data)
+	s.asserter.Assert(s.ctx, ok, msg, data...)
+}
+
+// That starts a fluent assertion whose failure is collected
+func (s *Soft) That(value any) *Chain {
+	return &Chain{ctx: s.ctx, asserter: s.asserter, msg: thatMsg, value: value, path: []string{"That"}}
+}
@@ -0,0 +1,58 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSoftly(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+	ctx := context.TODO()
+
+	err := handler.Softly(ctx, func(s *Soft) {
+		s.Equal(10, 12, "Total Mismatch")
+		s.NotNil(nil, "Customer Missing")
+		NotZero(s.Context(), "", "Currency Missing")
+		s.That(map[string]int{}).Key("sku").NotZero()
+		if exits != 0 {
+			t.Fatalf("Expected no exit inside the soft scope")
+		}
+	})
+	if exits != 1 {
+		t.Fatalf("Expected one exit at the end, got %d", exits)
+	}
+	var assertionErr *AssertionError
+	if !errors.As(err, &assertionErr) || assertionErr.Data["count"] != 4 {
+		t.Fatalf("Expected an aggregate of 4 failures, got %v", err)
+	}
+
+	handler.Assert(ctx, false, "Unrelated")
+	if exits != 2 || handler.DeferredCount() != 0 {
+		t.Fatalf("Expected unrelated call sites to fail immediately, got %d exits", exits)
+	}
+
+	buffer.Reset()
+	if err := handler.Softly(ctx, func(s *Soft) { s.Equal(1, 1, "Equal") }); err != nil || buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %v and %q", err, buffer.String())
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Expected the panic to propagate")
+			}
+		}()
+		handler.Softly(ctx, func(s *Soft) {
+			s.Assert(false, "Before Panic")
+			panic("boom")
+		})
+	}()
+	if !strings.Contains(buffer.String(), "Before Panic") || exits != 3 {
+		t.Fatalf("Expected failures before a panic to be reported, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,55 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Number is the constraint of MonotonicIncreasing
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sorted fails when slice is not sorted by less, which compares the elements at i and j like
+// the less function of sort.Slice. The first out-of-order index and the offending pair are
+// added to the failure data.
+func (a *AssertHandler) Sorted(ctx context.Context, slice any, less func(i, j int) bool, msg string, data ...any) {
+	ok, data := sorted(slice, less, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// Sorted fails through the default handler when slice is not sorted by less
+func Sorted(ctx context.Context, slice any, less func(i, j int) bool, msg string, data ...any) {
+	ok, data := sorted(slice, less, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// MonotonicIncreasing fails when an element of s is less than the one before it, reporting
+// the first such index and pair through the handler on ctx or the default handler. Equal
+// neighbours are allowed; NaN is out of order anywhere.
+func MonotonicIncreasing[T Number](ctx context.Context, s []T, msg string, data ...any) {
+	for i := 1; i < len(s); i++ {
+		// written so a NaN on either side fails
+		if !(s[i] >= s[i-1]) {
+			handlerFor(ctx).Assert(ctx, false, msg, append(data, "index", i, "pair", []T{s[i-1], s[i]}, "length", len(s))...)
+			return
+		}
+	}
+	passedFor(ctx, KindAssert)
+}
+
+func sorted(slice any, less func(i, j int) bool, data []any) (bool, []any) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false, append(data, "error", fmt.Sprintf("expected a slice, got %T", slice))
+	}
+	for i := 1; i < v.Len(); i++ {
+		if less(i, i-1) {
+			return false, append(data, "index", i, "pair", []any{v.Index(i - 1).Interface(), v.Index(i).Interface()}, "length", v.Len())
+		}
+	}
+	return true, data
+}
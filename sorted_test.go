@@ -0,0 +1,57 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSorted(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+
+	names := []string{"ada", "bob", "bob", "eve"}
+	handler.Sorted(ctx, names, func(i, j int) bool { return names[i] < names[j] }, "Names Unsorted")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failure, got %q", buffer.String())
+	}
+
+	names = []string{"ada", "eve", "bob"}
+	handler.Sorted(ctx, names, func(i, j int) bool { return names[i] < names[j] }, "Names Unsorted")
+	if !strings.Contains(buffer.String(), "index=2") || !strings.Contains(buffer.String(), "pair=[eve bob]") {
+		t.Fatalf("Expected the first out-of-order pair, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.Sorted(ctx, 42, func(i, j int) bool { return false }, "Not A Slice")
+	if !strings.Contains(buffer.String(), "expected a slice, got int") {
+		t.Fatalf("Expected a usage error, got %q", buffer.String())
+	}
+}
+
+func TestMonotonicIncreasing(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+
+	MonotonicIncreasing(ctx, []time.Duration{1, 2, 2, 5}, "Timestamps Went Back")
+	MonotonicIncreasing(ctx, []uint8{}, "Timestamps Went Back")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failure, got %q", buffer.String())
+	}
+
+	MonotonicIncreasing(ctx, []int64{10, 20, 15, 5}, "Timestamps Went Back")
+	if !strings.Contains(buffer.String(), "index=2") || !strings.Contains(buffer.String(), "pair=[20 15]") {
+		t.Fatalf("Expected the first decreasing pair, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	MonotonicIncreasing(ctx, []float64{1, math.NaN(), 3}, "Timestamps Went Back")
+	if !strings.Contains(buffer.String(), "index=1") {
+		t.Fatalf("Expected NaN to be out of order, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,58 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+)
+
+// spoolPreviewSize is how many bytes of a spooled value stay inline in the event
+const spoolPreviewSize = 256
+
+// WithSpoolDir writes data values whose rendering exceeds threshold bytes to a file in dir,
+// keeping only a preview in the event plus the file path under "<key>_spool"
+func WithSpoolDir(dir string, threshold int) Option {
+	return func(a *AssertHandler) {
+		a.spoolDir = dir
+		a.spoolThreshold = threshold
+	}
+}
+
+// spoolLargeValues replaces oversized values in data with previews backed by spool files
+func (a *AssertHandler) spoolLargeValues(data map[string]interface{}) {
+	if a.spoolDir == "" || a.spoolThreshold <= 0 {
+		return
+	}
+
+	for k, v := range data {
+		rendered := fmt.Sprint(v)
+		if len(rendered) <= a.spoolThreshold {
+			continue
+		}
+
+		path, err := spool(a.spoolDir, rendered)
+		if err != nil {
+			fmt.Fprintln(a.writer, "Spool error:", err)
+			continue
+		}
+
+		preview := rendered
+		if len(preview) > spoolPreviewSize {
+			preview = preview[:spoolPreviewSize]
+		}
+		data[k] = fmt.Sprintf("%s... (%d bytes)", preview, len(rendered))
+		data[k+"_spool"] = path
+	}
+}
+
+func spool(dir, value string) (string, error) {
+	f, err := os.CreateTemp(dir, "assert-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(value); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
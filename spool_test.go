@@ -0,0 +1,34 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSpoolLargeValues(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithSpoolDir(t.TempDir(), 1024))
+
+	payload := strings.Repeat("x", 4096)
+	handler.Assert(context.TODO(), false, "Large Payload", "payload", payload, "small", "ok")
+
+	if bytes.Contains(buffer.Bytes(), []byte(payload)) {
+		t.Fatalf("Expected the full payload to be kept out of the output")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte("(4096 bytes)")) || !bytes.Contains(buffer.Bytes(), []byte("small=ok")) {
+		t.Fatalf("Expected preview and untouched small value, got %s", buffer.String())
+	}
+
+	i := bytes.Index(buffer.Bytes(), []byte("payload_spool="))
+	if i < 0 {
+		t.Fatalf("Expected spool path in output")
+	}
+	path := strings.Fields(buffer.String()[i+len("payload_spool="):])[0]
+	contents, err := os.ReadFile(path)
+	if err != nil || string(contents) != payload {
+		t.Fatalf("Expected spool file to hold the full payload: %v", err)
+	}
+}
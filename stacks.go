@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// WithAllGoroutineStacks captures the stacks of all goroutines for every failure instead of
+// only the failing one, since a violated invariant in concurrent code is usually explained
+// by what the other goroutines were doing. The failing goroutine comes first.
+func WithAllGoroutineStacks() Option {
+	return func(a *AssertHandler) {
+		a.allStacks = true
+	}
+}
+
+// stack returns the stack of the failing goroutine, or of all goroutines with WithAllGoroutineStacks
+func (a *AssertHandler) stack() string {
+	if !a.allStacks {
+		return string(debug.Stack())
+	}
+	return string(allStacks())
+}
+
+// allStacks returns the stacks of all goroutines, growing the buffer until they fit
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// maxStackDepth bounds how many frames callerStack captures
+const maxStackDepth = 256
+
+// callerStack captures the program counters of the calling goroutine, so its stack can be
+// rendered later on another goroutine without losing the caller's frames
+func callerStack() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	return pcs[:n]
+}
+
+// renderStack renders pcs one frame per function and file:line pair, like debug.Stack
+// without the goroutine header and arguments
+func renderStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s(...)\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			return b.String()
+		}
+	}
+}
+
+// renderedStack returns the stack of the goroutine pcs were captured on, or of all
+// goroutines with WithAllGoroutineStacks, whose dump includes the waiting caller
+func (a *AssertHandler) renderedStack(pcs []uintptr) string {
+	if a.allStacks {
+		return string(allStacks())
+	}
+	return renderStack(pcs)
+}
@@ -0,0 +1,32 @@
+package assert
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAllGoroutineStacks(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		<-release
+	}()
+	<-started
+
+	handler := NewAssertHandler(WithExitFunc(func(code int) {}), WithDeferMode(true))
+	handler.Assert(context.TODO(), false, "Single")
+	handler.With(WithAllGoroutineStacks()).Assert(context.TODO(), false, "All")
+
+	events := handler.DeferredEvents()
+	if strings.Contains(events[0].Stack, "TestAllGoroutineStacks.func1") {
+		t.Fatalf("Expected only the failing goroutine by default")
+	}
+	if !strings.Contains(events[1].Stack, "TestAllGoroutineStacks.func1") || !strings.HasPrefix(events[1].Stack, "goroutine ") ||
+		!strings.Contains(events[1].Stack[:strings.Index(events[1].Stack, "\n\n")], "TestAllGoroutineStacks") {
+		t.Fatalf("Expected all goroutines with the failing one first, got %s", events[1].Stack)
+	}
+	handler.ClearDeferred()
+}
@@ -0,0 +1,193 @@
+package assert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// PolicyState is the runtime policy state an operator builds up while a process runs:
+// suppressed assertion codes and the escalation counters of failures per code
+type PolicyState struct {
+	Suppressed  []string         `json:"suppressed,omitempty"`
+	Escalations map[string]int64 `json:"escalations,omitempty"`
+}
+
+// StateStore persists policy state so operator decisions survive restarts and deploys
+type StateStore interface {
+	Load(ctx context.Context) (PolicyState, error)
+	Save(ctx context.Context, state PolicyState) error
+}
+
+// FileStateStore is a StateStore keeping the state as JSON in a single file.
+// A missing file loads as empty state; saves replace the file atomically.
+type FileStateStore struct {
+	Path string
+}
+
+// NewFileStateStore returns a StateStore backed by the file at path
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{Path: path}
+}
+
+func (s *FileStateStore) Load(ctx context.Context) (PolicyState, error) {
+	var state PolicyState
+	contents, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(contents, &state)
+	return state, err
+}
+
+func (s *FileStateStore) Save(ctx context.Context, state PolicyState) error {
+	contents, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// policyState is the live policy state; derived handlers share their parent's
+type policyState struct {
+	mu          sync.Mutex
+	store       StateStore
+	loaded      bool
+	suppressed  map[string]bool
+	escalations map[string]int64
+}
+
+func newPolicyState() *policyState {
+	return &policyState{
+		suppressed:  make(map[string]bool),
+		escalations: make(map[string]int64),
+	}
+}
+
+func (p *policyState) snapshot() PolicyState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := PolicyState{Escalations: make(map[string]int64, len(p.escalations))}
+	for code := range p.suppressed {
+		state.Suppressed = append(state.Suppressed, code)
+	}
+	sort.Strings(state.Suppressed)
+	for code, n := range p.escalations {
+		state.Escalations[code] = n
+	}
+	return state
+}
+
+// observe counts a failure with the given code and reports whether it is suppressed
+func (p *policyState) observe(code string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.escalations[code]++
+	return p.suppressed[code]
+}
+
+// WithStateStore restores suppressions and escalation counters from store and persists
+// them back when suppressions change, on Shutdown and before exiting; escalations counted
+// in between are saved with them. The state is shared with handlers derived from this one
+// and loaded only once, so applying the option again, e.g. through With, only replaces
+// the store.
+func WithStateStore(store StateStore) Option {
+	return func(a *AssertHandler) {
+		p := a.policy
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.store = store
+		if p.loaded {
+			return
+		}
+		p.loaded = true
+
+		state, err := store.Load(context.Background())
+		if err != nil {
+			fmt.Fprintln(a.writer, "State store error:", err)
+		}
+		for _, code := range state.Suppressed {
+			p.suppressed[code] = true
+		}
+		for code, n := range state.Escalations {
+			p.escalations[code] += n
+		}
+	}
+}
+
+// Suppress stops failures with the given code from being reported or exiting the process.
+// They are still counted by Escalations.
+func (a *AssertHandler) Suppress(ctx context.Context, code string) error {
+	a.policy.mu.Lock()
+	a.policy.suppressed[code] = true
+	a.policy.mu.Unlock()
+	return a.SaveState(ctx)
+}
+
+// Unsuppress reverts Suppress
+func (a *AssertHandler) Unsuppress(ctx context.Context, code string) error {
+	a.policy.mu.Lock()
+	delete(a.policy.suppressed, code)
+	a.policy.mu.Unlock()
+	return a.SaveState(ctx)
+}
+
+// Suppressed reports whether failures with the given code are suppressed
+func (a *AssertHandler) Suppressed(code string) bool {
+	a.policy.mu.Lock()
+	defer a.policy.mu.Unlock()
+	return a.policy.suppressed[code]
+}
+
+// Escalations returns how many failures with the given code were seen, including those
+// counted before a restart when a StateStore is configured
+func (a *AssertHandler) Escalations(code string) int64 {
+	a.policy.mu.Lock()
+	defer a.policy.mu.Unlock()
+	return a.policy.escalations[code]
+}
+
+// SaveState persists the current policy state to the configured StateStore, if any
+func (a *AssertHandler) SaveState(ctx context.Context) error {
+	a.policy.mu.Lock()
+	store := a.policy.store
+	a.policy.mu.Unlock()
+	if store == nil {
+		return nil
+	}
+	return store.Save(ctx, a.policy.snapshot())
+}
+
+// suppress counts a failure against its code and reports whether it is suppressed.
+// Failures without a code are never suppressed.
+func (a *AssertHandler) suppress(data map[string]interface{}) bool {
+	code, ok := data["code"]
+	if !ok {
+		return false
+	}
+	return a.policy.observe(fmt.Sprint(code))
+}
@@ -0,0 +1,59 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestStateStorePersistsAcrossRestarts(t *testing.T) {
+	var buffer bytes.Buffer
+	store := NewFileStateStore(filepath.Join(t.TempDir(), "state.json"))
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithStateStore(store))
+
+	handler.Assert(context.TODO(), false, "Cache Stale", "code", "CACHE-1")
+	if err := handler.Suppress(context.TODO(), "CACHE-1"); err != nil {
+		t.Fatalf("Suppress failed: %v", err)
+	}
+	buffer.Reset()
+	handler.Assert(context.TODO(), false, "Cache Stale", "code", "CACHE-1")
+	if buffer.Len() != 0 || exits != 1 {
+		t.Fatalf("Expected the suppressed failure to be silent, got %q", buffer.String())
+	}
+	if err := handler.Shutdown(context.TODO()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	restarted := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }), WithStateStore(store))
+	if !restarted.Suppressed("CACHE-1") || restarted.Escalations("CACHE-1") != 2 {
+		t.Fatalf("Expected state to survive the restart, got suppressed=%v escalations=%d",
+			restarted.Suppressed("CACHE-1"), restarted.Escalations("CACHE-1"))
+	}
+
+	if err := restarted.Unsuppress(context.TODO(), "CACHE-1"); err != nil {
+		t.Fatalf("Unsuppress failed: %v", err)
+	}
+	restarted.Assert(context.TODO(), false, "Cache Stale", "code", "CACHE-1")
+	if exits != 2 {
+		t.Fatalf("Expected the failure to exit again once unsuppressed")
+	}
+	state, err := store.Load(context.TODO())
+	if err != nil || len(state.Suppressed) != 0 || state.Escalations["CACHE-1"] != 3 {
+		t.Fatalf("Expected the exit path to save the state, got %+v, %v", state, err)
+	}
+}
+
+func TestStateStoreLoadsOnce(t *testing.T) {
+	store := NewFileStateStore(filepath.Join(t.TempDir(), "state.json"))
+	if err := store.Save(context.TODO(), PolicyState{Escalations: map[string]int64{"DB-1": 5}}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	handler := NewAssertHandler(WithWriter(&bytes.Buffer{}), WithStateStore(store))
+	derived := handler.With(WithStateStore(store)).With(WithStateStore(store))
+	if n := derived.Escalations("DB-1"); n != 5 {
+		t.Fatalf("Expected the persisted escalations to be loaded once, got %d", n)
+	}
+}
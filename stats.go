@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"context"
+	"sync"
+)
+
+// Counts are the number of evaluated, passed and failed assertions. Evaluated is only
+// counted with WithStats, since without it passing assertions go unseen.
+type Counts struct {
+	Evaluated int64 `json:"evaluated"`
+	Passed    int64 `json:"passed"`
+	Failed    int64 `json:"failed"`
+}
+
+func (c *Counts) add(passed, evaluated bool) {
+	if evaluated {
+		c.Evaluated++
+	}
+	if passed {
+		c.Passed++
+	} else {
+		c.Failed++
+	}
+}
+
+// Stats are the assertion counters of a handler since it was created or ResetStats was
+// called, in total, per kind and per call site as file:line. Reported failures and the
+// passes recorded with Pass are always counted; other passes and the evaluations only for
+// handlers created with WithStats, since finding the call site of every passing assertion
+// has a cost on hot paths.
+type Stats struct {
+	Counts
+	Kinds     map[Kind]Counts   `json:"kinds"`
+	CallSites map[string]Counts `json:"call_sites"`
+}
+
+// assertStats holds the counters behind Stats; derived handlers share their parent's
+type assertStats struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+func newAssertStats() *assertStats {
+	s := &assertStats{}
+	s.reset()
+	return s
+}
+
+func (s *assertStats) reset() {
+	s.stats = Stats{Kinds: make(map[Kind]Counts), CallSites: make(map[string]Counts)}
+}
+
+func (s *assertStats) record(kind Kind, site string, passed, evaluated bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.add(passed, evaluated)
+	k := s.stats.Kinds[kind]
+	k.add(passed, evaluated)
+	s.stats.Kinds[kind] = k
+	c := s.stats.CallSites[site]
+	c.add(passed, evaluated)
+	s.stats.CallSites[site] = c
+}
+
+// WithStats counts passing and evaluated assertions in Stats as well as failing ones, for
+// every assertion of the handler and the package-level assertions resolving to it.
+func WithStats() Option {
+	return func(a *AssertHandler) {
+		a.countPasses.Store(true)
+	}
+}
+
+// Stats returns a copy of the handler's assertion counters, e.g. to check in a test that no
+// assertion fired or to serve on a debug endpoint
+func (a *AssertHandler) Stats() Stats {
+	a.stats.mu.Lock()
+	defer a.stats.mu.Unlock()
+	out := Stats{
+		Counts:    a.stats.stats.Counts,
+		Kinds:     make(map[Kind]Counts, len(a.stats.stats.Kinds)),
+		CallSites: make(map[string]Counts, len(a.stats.stats.CallSites)),
+	}
+	for k, v := range a.stats.stats.Kinds {
+		out.Kinds[k] = v
+	}
+	for k, v := range a.stats.stats.CallSites {
+		out.CallSites[k] = v
+	}
+	return out
+}
+
+// ResetStats sets the handler's assertion counters back to zero
+func (a *AssertHandler) ResetStats() {
+	a.stats.mu.Lock()
+	defer a.stats.mu.Unlock()
+	a.stats.reset()
+}
+
+// evaluate counts a passing assertion of kind, or reports it as a failure when ok is false.
+// Handler methods go through it so Stats counts them like their package-level forms.
+func (a *AssertHandler) evaluate(ctx context.Context, kind Kind, ok bool, msg string, data []any) {
+	if ok {
+		a.passed(kind)
+		return
+	}
+	a.report(ctx, failure{severity: SeverityError, kind: kind, msg: msg, args: data})
+}
+
+// passed counts a passing assertion of kind when passes are counted
+func (a *AssertHandler) passed(kind Kind) {
+	if a.countPasses.Load() {
+		a.stats.record(kind, callSiteLocation(), true, true)
+	}
+}
+
+// passedFor counts a passing package-level assertion of kind on the handler on ctx or the
+// default handler
+func passedFor(ctx context.Context, kind Kind) {
+	if h, ok := handlerFor(ctx).(*AssertHandler); ok {
+		h.passed(kind)
+	}
+}
+
+// recordFailure counts a failing assertion of kind once it is past the call site limit and
+// the cancellation check. Callers must hold flushLock.
+func (a *AssertHandler) recordFailure(kind Kind) {
+	a.stats.record(kind, callSiteLocation(), false, a.countPasses.Load())
+}
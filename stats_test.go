@@ -0,0 +1,134 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsCountFailures(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	handler.Assert(context.TODO(), true, "Passing")
+	if stats := handler.Stats(); stats.Failed != 0 || stats.Evaluated != 0 {
+		t.Fatalf("Expected passes not to be counted without WithStats, got %+v", stats.Counts)
+	}
+
+	handler.Assert(context.TODO(), false, "Failing")
+	handler.Invariant(context.TODO(), false, "Broken")
+	stats := handler.Stats()
+	if stats.Failed != 2 || stats.Kinds[KindAssert].Failed != 1 || stats.Kinds[KindInvariant].Failed != 1 {
+		t.Fatalf("Expected failures per kind, got %+v", stats)
+	}
+	if stats.Evaluated != 0 {
+		t.Fatalf("Expected evaluations not to be counted without WithStats, got %d", stats.Evaluated)
+	}
+	if len(stats.CallSites) != 2 {
+		t.Fatalf("Expected failures per call site, got %v", stats.CallSites)
+	}
+
+	handler.ResetStats()
+	if stats := handler.Stats(); stats.Failed != 0 || len(stats.Kinds) != 0 || len(stats.CallSites) != 0 {
+		t.Fatalf("Expected ResetStats to clear the counters, got %+v", stats)
+	}
+}
+
+func TestStatsCountPasses(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithStats())
+	ctx := NewContext(context.TODO(), handler)
+
+	for i := 0; i < 3; i++ {
+		handler.Assert(ctx, i != 2, "Loop Check")
+	}
+	NoError(ctx, nil, "No Error")
+	Require(ctx, true, "Input Valid")
+	handler.NotNil(ctx, errors.New("set"), "Error Set")
+
+	stats := handler.Stats()
+	if stats.Evaluated != 6 || stats.Passed != 5 || stats.Failed != 1 {
+		t.Fatalf("Expected evaluated, passed and failed totals, got %+v", stats.Counts)
+	}
+	if got := stats.Kinds[KindPrecondition]; got.Passed != 1 {
+		t.Fatalf("Expected the precondition to be counted under its kind, got %+v", got)
+	}
+
+	var loop Counts
+	for site, counts := range stats.CallSites {
+		if counts.Evaluated == 3 {
+			loop = counts
+			if !strings.Contains(site, "stats_test.go:") {
+				t.Fatalf("Expected the call site as file:line, got %q", site)
+			}
+		}
+	}
+	if loop.Passed != 2 || loop.Failed != 1 {
+		t.Fatalf("Expected the loop call site to count its passes and failure, got %v", stats.CallSites)
+	}
+}
+
+func TestStatsCountMethodForms(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithStats())
+	ctx := NewContext(context.TODO(), handler)
+
+	handler.Equal(ctx, 1, 1, "Equal")
+	handler.HasPrefix(ctx, "assert", "as", "Prefix")
+	handler.Zero(ctx, 0, "Zero")
+	handler.HasKey(ctx, map[string]int{"a": 1}, "a", "Key")
+	handler.ValidEmail(ctx, "ada@example.com", "Email")
+	Equal(ctx, 1, 1, "Package Equal")
+
+	stats := handler.Stats()
+	if stats.Evaluated != 6 || stats.Passed != 6 || stats.Kinds[KindValidation].Passed != 1 {
+		t.Fatalf("Expected method and package-level forms to count alike, got %+v", stats)
+	}
+}
+
+func TestStatsCountExplicitPasses(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	handler.Pass(context.TODO(), "LEDGER-1", "Ledger Balanced")
+	if stats := handler.Stats(); stats.Passed != 1 || stats.Evaluated != 0 {
+		t.Fatalf("Expected the explicit pass to be counted, got %+v", stats.Counts)
+	}
+}
+
+func TestStatsCountHelperPasses(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithStats())
+	ctx := NewContext(context.TODO(), handler)
+
+	closed := make(chan int)
+	close(closed)
+	MonotonicIncreasing(ctx, []int{1, 2, 2}, "Increasing")
+	Closed(ctx, closed, "Closed")
+	NoReceiveWithin(ctx, make(chan int), time.Millisecond, "Quiet")
+	MustNoError(ctx, nil, "No Error")
+	handler.MustAssert(ctx, true, "Holds")
+
+	if stats := handler.Stats(); stats.Evaluated != 5 || stats.Passed != 5 {
+		t.Fatalf("Expected the helpers to count their passes, got %+v", stats.Counts)
+	}
+}
+
+func TestStatsSkipDroppedFailures(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithReportOnce())
+
+	for i := 0; i < 3; i++ {
+		handler.Assert(context.TODO(), false, "Loop Check")
+	}
+	canceled, cancel := context.WithCancel(context.TODO())
+	cancel()
+	handler.Assert(canceled, false, "Canceled")
+
+	if stats := handler.Stats(); stats.Failed != 1 {
+		t.Fatalf("Expected only the reported failure to be counted, got %+v", stats.Counts)
+	}
+}
@@ -0,0 +1,46 @@
+package assert
+
+import (
+	"context"
+	"strings"
+)
+
+// HasPrefix fails when s does not start with prefix
+func (a *AssertHandler) HasPrefix(ctx context.Context, s, prefix string, msg string, data ...any) {
+	a.evaluate(ctx, KindAssert, strings.HasPrefix(s, prefix), msg, append(data, "value", s, "prefix", prefix))
+}
+
+// HasPrefix fails through the default handler when s does not start with prefix
+func HasPrefix(ctx context.Context, s, prefix string, msg string, data ...any) {
+	handlerFor(ctx).Assert(ctx, strings.HasPrefix(s, prefix), msg, append(data, "value", s, "prefix", prefix)...)
+}
+
+// HasSuffix fails when s does not end with suffix
+func (a *AssertHandler) HasSuffix(ctx context.Context, s, suffix string, msg string, data ...any) {
+	a.evaluate(ctx, KindAssert, strings.HasSuffix(s, suffix), msg, append(data, "value", s, "suffix", suffix))
+}
+
+// HasSuffix fails through the default handler when s does not end with suffix
+func HasSuffix(ctx context.Context, s, suffix string, msg string, data ...any) {
+	handlerFor(ctx).Assert(ctx, strings.HasSuffix(s, suffix), msg, append(data, "value", s, "suffix", suffix)...)
+}
+
+// EqualFold fails when expected and actual differ other than in Unicode case
+func (a *AssertHandler) EqualFold(ctx context.Context, expected, actual string, msg string, data ...any) {
+	a.evaluate(ctx, KindAssert, strings.EqualFold(expected, actual), msg, append(data, "expected", expected, "actual", actual))
+}
+
+// EqualFold fails through the default handler when expected and actual differ other than in case
+func EqualFold(ctx context.Context, expected, actual string, msg string, data ...any) {
+	handlerFor(ctx).Assert(ctx, strings.EqualFold(expected, actual), msg, append(data, "expected", expected, "actual", actual)...)
+}
+
+// NotBlank fails when s is empty or only whitespace
+func (a *AssertHandler) NotBlank(ctx context.Context, s string, msg string, data ...any) {
+	a.evaluate(ctx, KindAssert, strings.TrimSpace(s) != "", msg, append(data, "value", s))
+}
+
+// NotBlank fails through the default handler when s is empty or only whitespace
+func NotBlank(ctx context.Context, s string, msg string, data ...any) {
+	handlerFor(ctx).Assert(ctx, strings.TrimSpace(s) != "", msg, append(data, "value", s)...)
+}
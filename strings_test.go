@@ -0,0 +1,42 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStringAssertions(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+
+	handler.HasPrefix(ctx, "https://example.com", "https://", "Insecure URL")
+	handler.HasSuffix(ctx, "config.yaml", ".yaml", "Not YAML")
+	handler.EqualFold(ctx, "Straße", "STRASSE", "Case Mismatch")
+	handler.EqualFold(ctx, "Go", "GO", "Case Mismatch")
+	handler.NotBlank(ctx, " x ", "Name Blank")
+	if strings.Count(buffer.String(), "msg=") != 1 || !strings.Contains(buffer.String(), "expected=Straße") {
+		t.Fatalf("Expected only the special-cased fold to fail, got %q", buffer.String())
+	}
+
+	cases := []struct {
+		check func()
+		want  []string
+	}{
+		{func() { handler.HasPrefix(ctx, "http://example.com", "https://", "Insecure URL") }, []string{"value=http://example.com", "prefix=https://"}},
+		{func() { handler.HasSuffix(ctx, "config.json", ".yaml", "Not YAML") }, []string{"value=config.json", "suffix=.yaml"}},
+		{func() { handler.EqualFold(ctx, "admin", "root", "Role Mismatch") }, []string{"expected=admin", "actual=root"}},
+		{func() { handler.NotBlank(ctx, " \t\n", "Name Blank") }, []string{"msg=Name Blank", "value="}},
+	}
+	for _, c := range cases {
+		buffer.Reset()
+		c.check()
+		for _, want := range c.want {
+			if !strings.Contains(buffer.String(), want) {
+				t.Fatalf("Expected %q in the failure, got %q", want, buffer.String())
+			}
+		}
+	}
+}
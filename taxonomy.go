@@ -0,0 +1,152 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TaxonomyEntry describes one kind of assertion failure: a code, area, severity and message
+// combination declared up front or seen at runtime
+type TaxonomyEntry struct {
+	Code      string    `json:"code,omitempty"`
+	Area      string    `json:"area"`
+	Severity  Severity  `json:"-"`
+	Message   string    `json:"message"`
+	Caller    string    `json:"caller,omitempty"`
+	Count     int64     `json:"count"`
+	Passes    int64     `json:"passes"`
+	FirstSeen time.Time `json:"first_seen,omitempty"`
+	LastSeen  time.Time `json:"last_seen,omitempty"`
+}
+
+// MarshalJSON renders the severity by name
+func (e TaxonomyEntry) MarshalJSON() ([]byte, error) {
+	type entry TaxonomyEntry
+	return json.Marshal(struct {
+		entry
+		Severity  string     `json:"severity"`
+		FirstSeen *time.Time `json:"first_seen,omitempty"`
+		LastSeen  *time.Time `json:"last_seen,omitempty"`
+	}{
+		entry:     entry(e),
+		Severity:  e.Severity.String(),
+		FirstSeen: timeOrNil(e.FirstSeen),
+		LastSeen:  timeOrNil(e.LastSeen),
+	})
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+type taxonomyKey struct {
+	code, area, msg string
+	severity        Severity
+}
+
+// taxonomy is the runtime registry of failures; derived handlers share their parent's
+type taxonomy struct {
+	mu      sync.Mutex
+	entries map[taxonomyKey]*TaxonomyEntry
+	passes  map[string]int64
+}
+
+func (t *taxonomy) pass(code string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.passes == nil {
+		t.passes = make(map[string]int64)
+	}
+	t.passes[code]++
+}
+
+func (t *taxonomy) record(entry TaxonomyEntry, seen bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries == nil {
+		t.entries = make(map[taxonomyKey]*TaxonomyEntry)
+	}
+
+	key := taxonomyKey{code: entry.Code, area: entry.Area, msg: entry.Message, severity: entry.Severity}
+	existing, ok := t.entries[key]
+	if !ok {
+		existing = &entry
+		existing.Count = 0
+		t.entries[key] = existing
+	}
+	if !seen {
+		return
+	}
+	if existing.Caller == "" {
+		existing.Caller = entry.Caller
+	}
+	now := time.Now()
+	if existing.FirstSeen.IsZero() {
+		existing.FirstSeen = now
+	}
+	existing.LastSeen = now
+	existing.Count++
+}
+
+// Declare registers an assertion in the taxonomy before it has ever failed, so catalogs
+// generated from Taxonomy also list invariants that hold. An empty Area defaults to "Assert".
+func (a *AssertHandler) Declare(entry TaxonomyEntry) {
+	if entry.Area == "" {
+		entry.Area = "Assert"
+	}
+	a.taxonomy.record(entry, false)
+}
+
+// Taxonomy returns every declared or reported assertion, ordered by code, area and message
+func (a *AssertHandler) Taxonomy() []TaxonomyEntry {
+	a.taxonomy.mu.Lock()
+	entries := make([]TaxonomyEntry, 0, len(a.taxonomy.entries))
+	for _, e := range a.taxonomy.entries {
+		entry := *e
+		if entry.Code != "" {
+			entry.Passes = a.taxonomy.passes[entry.Code]
+		}
+		entries = append(entries, entry)
+	}
+	a.taxonomy.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		x, y := entries[i], entries[j]
+		if x.Code != y.Code {
+			return x.Code < y.Code
+		}
+		if x.Area != y.Area {
+			return x.Area < y.Area
+		}
+		if x.Message != y.Message {
+			return x.Message < y.Message
+		}
+		return x.Severity < y.Severity
+	})
+	return entries
+}
+
+// WriteTaxonomy writes the taxonomy to w as a JSON array, e.g. to generate an invariant catalog
+func (a *AssertHandler) WriteTaxonomy(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(a.Taxonomy())
+}
+
+// recordTaxonomy adds a reported failure to the taxonomy
+func (a *AssertHandler) recordTaxonomy(severity Severity, msg string, data map[string]interface{}) {
+	entry := TaxonomyEntry{Severity: severity, Message: msg}
+	if code, ok := data["code"]; ok {
+		entry.Code = fmt.Sprint(code)
+	}
+	entry.Area, _ = data["area"].(string)
+	entry.Caller, _ = data["caller"].(string)
+	a.taxonomy.record(entry, true)
+}
@@ -0,0 +1,43 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTaxonomy(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	handler.Declare(TaxonomyEntry{Code: "INV-1", Severity: SeverityFatal, Message: "Ledger Balanced"})
+	handler.Assert(context.TODO(), false, "Queue Drained", "code", "INV-2")
+	handler.WithTags("subsystem", "billing").Assert(context.TODO(), false, "Queue Drained", "code", "INV-2")
+
+	entries := handler.Taxonomy()
+	if len(entries) != 2 {
+		t.Fatalf("Expected two taxonomy entries, got %+v", entries)
+	}
+	if entries[0].Code != "INV-1" || entries[0].Count != 0 || !entries[0].LastSeen.IsZero() {
+		t.Fatalf("Expected the declared entry to be unseen, got %+v", entries[0])
+	}
+	if entries[1].Code != "INV-2" || entries[1].Count != 2 || entries[1].Caller == "" {
+		t.Fatalf("Expected the reported entry to be counted across derived handlers, got %+v", entries[1])
+	}
+
+	var out bytes.Buffer
+	if err := handler.WriteTaxonomy(&out); err != nil {
+		t.Fatalf("WriteTaxonomy failed: %v", err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected JSON output: %v", err)
+	}
+	if decoded[0]["severity"] != "FATAL" || decoded[0]["area"] != "Assert" || decoded[0]["first_seen"] != nil {
+		t.Fatalf("Unexpected JSON entry: %v", decoded[0])
+	}
+	if decoded[1]["count"] != float64(2) || decoded[1]["message"] != "Queue Drained" {
+		t.Fatalf("Unexpected JSON entry: %v", decoded[1])
+	}
+}
@@ -0,0 +1,94 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// TemplateEvent is the data a TemplateFormatter template is executed with
+type TemplateEvent struct {
+	Msg      string
+	Severity string
+	Area     string
+	Caller   string
+	// Fingerprint groups the occurrences of the same failure
+	Fingerprint string
+	// Data holds every field of the failure, including the ones above
+	Data  map[string]interface{}
+	Stack string
+}
+
+// Fields returns the data keys other than msg, severity, area, caller and fingerprint, sorted
+func (e TemplateEvent) Fields() []string {
+	keys := make([]string, 0, len(e.Data))
+	for k := range e.Data {
+		if !cliReservedKeys[k] && k != fingerprintKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// templateFuncs are available to every TemplateFormatter template
+var templateFuncs = map[string]any{
+	"json": func(v any) (string, error) {
+		out, err := json.Marshal(v)
+		return string(out), err
+	},
+	"join": strings.Join,
+}
+
+type templateExecutor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// TemplateFormatter renders failures with a user-supplied template so output can match an
+// existing log format exactly, e.g.
+//
+//	{{.Severity}} [{{.Area}}] {{.Msg}}{{range .Fields}} {{.}}={{index $.Data .}}{{end}}
+//
+// Templates can use the json and join functions.
+type TemplateFormatter struct {
+	tmpl templateExecutor
+}
+
+// NewTemplateFormatter parses tmpl as a text/template over TemplateEvent
+func NewTemplateFormatter(tmpl string) (*TemplateFormatter, error) {
+	t, err := template.New("assert").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateFormatter{tmpl: t}, nil
+}
+
+// NewHTMLTemplateFormatter parses tmpl as an html/template over TemplateEvent, escaping the
+// failure data for embedding in HTML reports
+func NewHTMLTemplateFormatter(tmpl string) (*TemplateFormatter, error) {
+	t, err := htmltemplate.New("assert").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateFormatter{tmpl: t}, nil
+}
+
+func (f *TemplateFormatter) Format(assertData map[string]interface{}, stack string) string {
+	event := TemplateEvent{Data: assertData, Stack: stack}
+	event.Msg, _ = assertData["msg"].(string)
+	event.Severity, _ = assertData["severity"].(string)
+	event.Area, _ = assertData["area"].(string)
+	event.Caller, _ = assertData["caller"].(string)
+	event.Fingerprint, _ = assertData[fingerprintKey].(string)
+
+	var buf bytes.Buffer
+	if err := f.tmpl.Execute(&buf, event); err != nil {
+		return fmt.Sprintf("template error: %v (msg=%s)", err, event.Msg)
+	}
+	return buf.String()
+}
@@ -0,0 +1,35 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTemplateFormatter(t *testing.T) {
+	formatter, err := NewTemplateFormatter(`{{.Severity}} [{{.Area}}] {{.Msg}}{{range .Fields}} {{.}}={{index $.Data . | json}}{{end}}`)
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithFormatter(formatter))
+	handler.Assert(context.TODO(), false, "Quota Exceeded", "user", "ada", "used", 12)
+
+	if !strings.Contains(buffer.String(), `ERROR [Assert] Quota Exceeded used=12 user="ada"`) {
+		t.Fatalf("Unexpected template output: %q", buffer.String())
+	}
+
+	if _, err := NewTemplateFormatter("{{.Msg"); err == nil {
+		t.Fatalf("Expected a parse error for an invalid template")
+	}
+
+	html, err := NewHTMLTemplateFormatter(`<p>{{.Msg}}</p>`)
+	if err != nil {
+		t.Fatalf("Failed to parse HTML template: %v", err)
+	}
+	if out := html.Format(map[string]interface{}{"msg": "<b>bad</b>"}, ""); out != "<p>&lt;b&gt;bad&lt;/b&gt;</p>" {
+		t.Fatalf("Expected escaped HTML, got %q", out)
+	}
+}
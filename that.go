@@ -0,0 +1,209 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// thatMsg is the message of failures reported by a Chain without Msg
+const thatMsg = "Chained Assertion Failed"
+
+// Chain is a fluent assertion on one value, started with That. Each step checks the current
+// value, or descends into it with Field and Key. The chain stops at its first failure, which
+// is reported with the path of the steps taken so far:
+//
+//	assert.That(ctx, cfg).NotNil().Field("Server").Field("Port").NotZero()
+type Chain struct {
+	ctx      context.Context
+	asserter Asserter
+	msg      string
+	value    any
+	path     []string
+	failed   bool
+}
+
+// That starts a fluent assertion on value reporting through a
+func (a *AssertHandler) That(ctx context.Context, value any) *Chain {
+	return &Chain{ctx: ctx, asserter: a, msg: thatMsg, value: value, path: []string{"That"}}
+}
+
+// That starts a fluent assertion on value reporting through the handler on ctx or the
+// default handler
+func That(ctx context.Context, value any) *Chain {
+	return &Chain{ctx: ctx, asserter: handlerFor(ctx), msg: thatMsg, value: value, path: []string{"That"}}
+}
+
+// Msg sets the message failures of the chain are reported with
+func (c *Chain) Msg(msg string) *Chain {
+	c.msg = msg
+	return c
+}
+
+// Value returns the current value of the chain, nil after a failure
+func (c *Chain) Value() any {
+	if c.failed {
+		return nil
+	}
+	return c.value
+}
+
+// Failed reports whether a step of the chain failed
+func (c *Chain) Failed() bool {
+	return c.failed
+}
+
+// NotNil fails when the value is nil or a nil pointer, map, slice, channel or func
+func (c *Chain) NotNil() *Chain {
+	return c.step("NotNil()", func(v any) (bool, []any) {
+		return !isNil(v), []any{"value", v}
+	})
+}
+
+// Nil fails when the value is not nil
+func (c *Chain) Nil() *Chain {
+	return c.step("Nil()", func(v any) (bool, []any) {
+		return isNil(v), []any{"value", v}
+	})
+}
+
+// Zero fails when the value is not the zero value of its type
+func (c *Chain) Zero() *Chain {
+	return c.step("Zero()", func(v any) (bool, []any) {
+		return zero(v, true, nil)
+	})
+}
+
+// NotZero fails when the value is nil or the zero value of its type
+func (c *Chain) NotZero() *Chain {
+	return c.step("NotZero()", func(v any) (bool, []any) {
+		return zero(v, false, nil)
+	})
+}
+
+// NotEmpty fails when the value is a string, slice, array, map or channel of length 0, or
+// any other value that is zero
+func (c *Chain) NotEmpty() *Chain {
+	return c.step("NotEmpty()", func(v any) (bool, []any) {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			return rv.Len() > 0, []any{"value", v, "length", rv.Len()}
+		}
+		return zero(v, false, nil)
+	})
+}
+
+// Equal fails when the value is not deeply equal to expected
+func (c *Chain) Equal(expected any) *Chain {
+	return c.step(fmt.Sprintf("Equal(%v)", expected), func(v any) (bool, []any) {
+		return equal(expected, v, nil)
+	})
+}
+
+// IsType fails when the value does not have the dynamic type of expectedSample
+func (c *Chain) IsType(expectedSample any) *Chain {
+	return c.step(fmt.Sprintf("IsType(%T)", expectedSample), func(v any) (bool, []any) {
+		return isType(expectedSample, v, nil)
+	})
+}
+
+// Implements fails when the value does not implement the interface iface points to
+func (c *Chain) Implements(iface any) *Chain {
+	name := typeName(reflect.TypeOf(iface))
+	if t := reflect.TypeOf(iface); t != nil && t.Kind() == reflect.Pointer {
+		name = typeName(t.Elem())
+	}
+	return c.step("Implements("+name+")", func(v any) (bool, []any) {
+		return implements(iface, v, nil)
+	})
+}
+
+// Satisfies fails when fn returns false for the value; name describes the check in the path
+func (c *Chain) Satisfies(name string, fn func(v any) bool) *Chain {
+	return c.step("Satisfies("+name+")", func(v any) (bool, []any) {
+		return fn(v), []any{"value", v}
+	})
+}
+
+// Field continues the chain with the named exported field of a struct or pointer to struct
+func (c *Chain) Field(name string) *Chain {
+	return c.descend("Field("+name+")", func(rv reflect.Value) (reflect.Value, string) {
+		for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Sprintf("expected a struct, got %s", typeName(rv.Type()))
+		}
+		field, ok := rv.Type().FieldByName(name)
+		if !ok || !field.IsExported() {
+			return reflect.Value{}, fmt.Sprintf("%s has no exported field %s", typeName(rv.Type()), name)
+		}
+		// a field promoted through a nil embedded pointer has no value
+		fv, err := rv.FieldByIndexErr(field.Index)
+		if err != nil {
+			return reflect.Value{}, err.Error()
+		}
+		return fv, ""
+	})
+}
+
+// Key continues the chain with the value of a map under key
+func (c *Chain) Key(key any) *Chain {
+	return c.descend(fmt.Sprintf("Key(%v)", key), func(rv reflect.Value) (reflect.Value, string) {
+		if rv.Kind() != reflect.Map {
+			return reflect.Value{}, fmt.Sprintf("expected a map, got %s", typeName(rv.Type()))
+		}
+		kv, err := mapKey(rv, key)
+		if err != nil {
+			return reflect.Value{}, err.Error()
+		}
+		value := rv.MapIndex(kv)
+		if !value.IsValid() {
+			return reflect.Value{}, fmt.Sprintf("no key %v", key)
+		}
+		return value, ""
+	})
+}
+
+// step adds name to the path and reports a failure when check fails
+func (c *Chain) step(name string, check func(v any) (bool, []any)) *Chain {
+	if c.failed {
+		return c
+	}
+	c.path = append(c.path, name)
+	if ok, data := check(c.value); !ok {
+		c.fail(data)
+	} else {
+		// lets the asserter count the passing step
+		c.asserter.Assert(c.ctx, true, c.msg)
+	}
+	return c
+}
+
+// descend adds name to the path and continues with the value next returns, failing with
+// the problem next reports
+func (c *Chain) descend(name string, next func(rv reflect.Value) (reflect.Value, string)) *Chain {
+	if c.failed {
+		return c
+	}
+	c.path = append(c.path, name)
+	rv := reflect.ValueOf(c.value)
+	if !rv.IsValid() {
+		c.fail([]any{"error", "value is nil"})
+		return c
+	}
+	value, problem := next(rv)
+	if problem != "" {
+		c.fail([]any{"error", problem})
+		return c
+	}
+	c.value = value.Interface()
+	return c
+}
+
+func (c *Chain) fail(data []any) {
+	c.failed = true
+	c.asserter.Assert(c.ctx, false, c.msg, append([]any{"path", strings.Join(c.path, ".")}, data...)...)
+}
@@ -0,0 +1,64 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type thatServer struct {
+	Name  string
+	Port  int
+	Peers map[string]*thatServer
+}
+
+func TestThat(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := NewContext(context.TODO(), handler)
+
+	server := &thatServer{Name: "api", Port: 8080, Peers: map[string]*thatServer{"db": {Name: "db"}}}
+	chain := That(ctx, server).NotNil().IsType(&thatServer{}).Implements((*fmt.Stringer)(nil))
+	if !chain.Failed() || !strings.Contains(buffer.String(), "path=That.NotNil().IsType(*assert.thatServer).Implements(fmt.Stringer)") {
+		t.Fatalf("Expected the failing path, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	if name := handler.That(ctx, server).NotNil().Field("Name").NotEmpty().Equal("api").Value(); name != "api" || buffer.Len() != 0 {
+		t.Fatalf("Expected the chain to pass with the field value, got %v and %q", name, buffer.String())
+	}
+
+	That(ctx, server).Msg("Peer Unconfigured").Field("Peers").Key("db").Field("Port").NotZero().Equal(5432)
+	for _, want := range []string{"msg=Peer Unconfigured", "path=That.Field(Peers).Key(db).Field(Port).NotZero()", "value=0"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the failure, got %q", want, buffer.String())
+		}
+	}
+	if strings.Count(buffer.String(), "msg=") != 1 {
+		t.Fatalf("Expected the chain to stop at the first failure, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	That(ctx, server).Field("Peers").Key("cache").NotNil()
+	if !strings.Contains(buffer.String(), "error=no key cache") {
+		t.Fatalf("Expected a missing key to fail, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	That(ctx, (*thatServer)(nil)).Field("Name")
+	if !strings.Contains(buffer.String(), "error=expected a struct, got *assert.thatServer") {
+		t.Fatalf("Expected a nil pointer to fail descending, got %q", buffer.String())
+	}
+
+	type thatReplica struct {
+		*thatServer
+		Lag int
+	}
+	buffer.Reset()
+	That(ctx, thatReplica{Lag: 3}).Field("Port")
+	if !strings.Contains(buffer.String(), "path=That.Field(Port)") || !strings.Contains(buffer.String(), "nil pointer to embedded struct") {
+		t.Fatalf("Expected a field behind a nil embedded pointer to fail the chain, got %q", buffer.String())
+	}
+}
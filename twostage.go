@@ -0,0 +1,243 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Enricher adds detail to a failure before its enriched report is emitted. Enrichers run on
+// a background goroutine with their own copy of the event data.
+type Enricher func(event *AssertionEvent)
+
+// TwoStageConfig configures WithTwoStageEmission. Zero values use defaults.
+type TwoStageConfig struct {
+	// Local renders the immediate report on the handler's writer, a FastFormatter if nil
+	Local Formatter
+	// Enrichers run before the enriched report is emitted, DiffEnricher, SourceEnricher(3)
+	// and RuntimeEnricher if nil
+	Enrichers []Enricher
+	// QueueSize bounds the failures waiting for enrichment (default 256). Failures arriving
+	// while the queue is full keep only their local report.
+	QueueSize int
+}
+
+// WithTwoStageEmission splits reporting in two: every failure is written at once in a minimal
+// form to the handler's writer, which also serves deferred processing and the crash file, so
+// the evidence survives a crash. The failure is then enriched in the background and the
+// enriched event is written to the additional writers, formatted with the handler's
+// formatter, and exported. Enrichment is drained before the process exits and on Shutdown.
+func WithTwoStageEmission(cfg TwoStageConfig) Option {
+	return func(a *AssertHandler) {
+		a.twoStage = newTwoStage(cfg)
+	}
+}
+
+// twoStage holds the enrichment queue, shared by a handler and its children
+type twoStage struct {
+	local     EventFormatter
+	enrichers []Enricher
+	queue     chan enrichJob
+	start     sync.Once
+	dropped   atomic.Uint64
+}
+
+// enrichJob is a failure waiting for enrichment with the sinks it goes to, or a flush
+// marker when done is set
+type enrichJob struct {
+	ctx       context.Context
+	event     AssertionEvent
+	formatter EventFormatter
+	writers   []teeWriter
+	exporters []Exporter
+	framing   RecordFraming
+	done      chan struct{}
+}
+
+func newTwoStage(cfg TwoStageConfig) *twoStage {
+	t := &twoStage{local: asEventFormatter(cfg.Local), enrichers: cfg.Enrichers}
+	if cfg.Local == nil {
+		t.local = &FastFormatter{}
+	}
+	if cfg.Enrichers == nil {
+		t.enrichers = []Enricher{DiffEnricher(), SourceEnricher(3), RuntimeEnricher()}
+	}
+	size := cfg.QueueSize
+	if size <= 0 {
+		size = 256
+	}
+	t.queue = make(chan enrichJob, size)
+	return t
+}
+
+// formatLocal renders the report written to the handler's writer. Callers must hold flushLock.
+func (a *AssertHandler) formatLocal(event AssertionEvent) string {
+	if a.twoStage == nil {
+		return a.format(event)
+	}
+	out, err := a.twoStage.local.FormatEvent(event)
+	if err != nil {
+		return a.format(event)
+	}
+	return string(out)
+}
+
+// emit sends a failure to the additional writers and the exporters, directly or through the
+// enrichment queue. Callers must hold flushLock.
+func (a *AssertHandler) emit(ctx context.Context, event AssertionEvent, formatted string) {
+	if a.twoStage == nil {
+		a.writeTee(event, formatted)
+		a.export(ctx, event)
+		return
+	}
+	a.twoStage.enqueue(enrichJob{
+		ctx:       context.WithoutCancel(ctx),
+		event:     event,
+		formatter: a.formatter,
+		writers:   append([]teeWriter(nil), a.writers...),
+		exporters: append([]Exporter(nil), a.exporters...),
+		framing:   a.framing,
+	})
+}
+
+// DroppedEnrichments returns how many failures kept only their local report because the
+// enrichment queue of WithTwoStageEmission was full
+func (a *AssertHandler) DroppedEnrichments() uint64 {
+	if a.twoStage == nil {
+		return 0
+	}
+	return a.twoStage.dropped.Load()
+}
+
+func (t *twoStage) enqueue(job enrichJob) {
+	t.start.Do(func() { go t.run() })
+	select {
+	case t.queue <- job:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// flush waits until the failures queued so far have been emitted or ctx is done
+func (t *twoStage) flush(ctx context.Context) error {
+	t.start.Do(func() { go t.run() })
+	done := make(chan struct{})
+	select {
+	case t.queue <- enrichJob{done: done}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *twoStage) run() {
+	for job := range t.queue {
+		if job.done != nil {
+			close(job.done)
+			continue
+		}
+		t.process(job)
+	}
+}
+
+func (t *twoStage) process(job enrichJob) {
+	event := job.event
+	// the handler and the deferred store keep the original data
+	data := make(map[string]interface{}, len(event.Data))
+	for k, v := range event.Data {
+		data[k] = v
+	}
+	event.Data = data
+	for _, enrich := range t.enrichers {
+		enrich(&event)
+	}
+
+	formatted, err := job.formatter.FormatEvent(event)
+	if err != nil {
+		formatted = []byte((&TextFormatter{}).Format(event.Data, event.Stack))
+	}
+	for _, tee := range job.writers {
+		out := formatted
+		if tee.formatter != nil {
+			if custom, err := tee.formatter.FormatEvent(event); err == nil {
+				out = custom
+			}
+		}
+		tee.w.Write(job.framing.record(string(out)))
+	}
+	batch := []AssertionEvent{event}
+	for _, e := range job.exporters {
+		if err := e.Export(job.ctx, batch); err != nil {
+			fmt.Fprintln(os.Stderr, "assert: exporter error:", err)
+		}
+	}
+}
+
+// DiffEnricher adds a diff of the "expected" and "actual" values when they can be diffed
+// and the failure has no diff yet
+func DiffEnricher() Enricher {
+	return func(event *AssertionEvent) {
+		expected, ok1 := event.Data["expected"]
+		actual, ok2 := event.Data["actual"]
+		if _, ok := event.Data["diff"]; ok || !ok1 || !ok2 {
+			return
+		}
+		if diff := Diff(expected, actual); diff != "" {
+			event.Data["diff"] = diff
+		}
+	}
+}
+
+// SourceEnricher adds the source lines around the caller under "source", with around lines
+// before and after the failing line, which is marked with >
+func SourceEnricher(around int) Enricher {
+	return func(event *AssertionEvent) {
+		i := strings.LastIndexByte(event.Caller, ':')
+		if i < 0 {
+			return
+		}
+		line, err := strconv.Atoi(event.Caller[i+1:])
+		if err != nil {
+			return
+		}
+		src, err := os.ReadFile(event.Caller[:i])
+		if err != nil {
+			return
+		}
+
+		lines := strings.Split(string(src), "\n")
+		var b strings.Builder
+		for n := max(line-around, 1); n <= min(line+around, len(lines)); n++ {
+			marker := " "
+			if n == line {
+				marker = ">"
+			}
+			fmt.Fprintf(&b, "%s%5d | %s\n", marker, n, lines[n-1])
+		}
+		event.Data["source"] = b.String()
+	}
+}
+
+// RuntimeEnricher adds the number of goroutines and the heap statistics at enrichment time
+func RuntimeEnricher() Enricher {
+	return func(event *AssertionEvent) {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		event.Data["runtime"] = map[string]interface{}{
+			"goroutines":   runtime.NumGoroutine(),
+			"heap_alloc":   m.HeapAlloc,
+			"heap_objects": m.HeapObjects,
+			"gc_cycles":    m.NumGC,
+		}
+	}
+}
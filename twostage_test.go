@@ -0,0 +1,88 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer safe for writes from the enrichment goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestTwoStageEmission(t *testing.T) {
+	var local bytes.Buffer
+	var heavy syncBuffer
+	exporter := &countingExporter{}
+	handler := NewAssertHandler(
+		WithWriter(&local),
+		WithWriters(&heavy),
+		WithExporter(exporter),
+		WithExitFunc(func(code int) {}),
+		WithTwoStageEmission(TwoStageConfig{}),
+		WithErrorSeverityMapper(func(error) Severity { return SeverityWarn }),
+	)
+
+	handler.NoError(context.TODO(), context.Canceled, "Two Stage", "expected", "a\nb", "actual", "a\nc")
+
+	if !strings.HasPrefix(local.String(), `ASSERT`+"\n"+`msg="Two Stage" fingerprint=`) || strings.Contains(local.String(), "source") {
+		t.Fatalf("Expected the minimal report locally, got %q", local.String())
+	}
+	if err := handler.Shutdown(context.TODO()); err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+
+	out := heavy.String()
+	for _, want := range []string{"msg=Two Stage", "diff=--- expected", "source=", `> `, "TwoStage", "runtime="} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("Expected %q in the enriched report, got %q", want, out)
+		}
+	}
+	events := exporter.events()
+	if len(events) != 1 || events[0].Data["source"] == nil {
+		t.Fatalf("Expected the enriched event to be exported, got %v", events)
+	}
+}
+
+func TestTwoStageDrainsBeforeExit(t *testing.T) {
+	var local bytes.Buffer
+	exporter := &countingExporter{}
+	exported := -1
+	handler := NewAssertHandler(
+		WithWriter(&local),
+		WithExporter(exporter),
+		WithExitFunc(func(code int) { exported = len(exporter.events()) }),
+		WithTwoStageEmission(TwoStageConfig{Enrichers: []Enricher{}}),
+	)
+
+	handler.Assert(context.TODO(), false, "Fatal Two Stage")
+	if exported != 1 {
+		t.Fatalf("Expected the enriched report to be exported before exit, got %d", exported)
+	}
+}
+
+func (c *countingExporter) events() []AssertionEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var events []AssertionEvent
+	for _, batch := range c.batches {
+		events = append(events, batch...)
+	}
+	return events
+}
@@ -0,0 +1,126 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// IsType fails when actual does not have the same dynamic type as expectedSample
+func (a *AssertHandler) IsType(ctx context.Context, expectedSample, actual any, msg string, data ...any) {
+	ok, data := isType(expectedSample, actual, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// IsType fails through the default handler when actual does not have the type of expectedSample
+func IsType(ctx context.Context, expectedSample, actual any, msg string, data ...any) {
+	ok, data := isType(expectedSample, actual, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// Implements fails when actual does not implement the interface iface points to, given as a
+// nil pointer like (*io.Reader)(nil)
+func (a *AssertHandler) Implements(ctx context.Context, iface, actual any, msg string, data ...any) {
+	ok, data := implements(iface, actual, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// Implements fails through the default handler when actual does not implement the interface
+// iface points to
+func Implements(ctx context.Context, iface, actual any, msg string, data ...any) {
+	ok, data := implements(iface, actual, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// IsKind fails when actual is not of the given kind, e.g. reflect.Pointer or reflect.Func
+func (a *AssertHandler) IsKind(ctx context.Context, kind reflect.Kind, actual any, msg string, data ...any) {
+	ok, data := isKind(kind, actual, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// IsKind fails through the default handler when actual is not of the given kind
+func IsKind(ctx context.Context, kind reflect.Kind, actual any, msg string, data ...any) {
+	ok, data := isKind(kind, actual, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+func isType(expectedSample, actual any, data []any) (bool, []any) {
+	expected, got := reflect.TypeOf(expectedSample), reflect.TypeOf(actual)
+	if expected == got {
+		return true, data
+	}
+	return false, append(data, "expected_type", typeName(expected), "actual_type", typeName(got))
+}
+
+func implements(iface, actual any, data []any) (bool, []any) {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Interface {
+		return false, append(data, "error", fmt.Sprintf("expected a nil pointer to an interface, got %s", typeName(t)))
+	}
+	got := reflect.TypeOf(actual)
+	if got != nil && got.Implements(t.Elem()) {
+		return true, data
+	}
+	data = append(data, "interface", typeName(t.Elem()), "actual_type", typeName(got))
+	if got != nil {
+		if missing := missingMethods(t.Elem(), got); len(missing) > 0 {
+			data = append(data, "missing_methods", missing)
+		}
+	}
+	return false, data
+}
+
+// missingMethods lists the methods of iface that t lacks or has with another signature
+func missingMethods(iface, t reflect.Type) []string {
+	var missing []string
+	for i := 0; i < iface.NumMethod(); i++ {
+		want := iface.Method(i)
+		got, ok := t.MethodByName(want.Name)
+		if !ok {
+			missing = append(missing, want.Name)
+			continue
+		}
+		// the method type of a concrete type includes the receiver
+		if t.Kind() != reflect.Interface {
+			in := make([]reflect.Type, 0, got.Type.NumIn())
+			for j := 1; j < got.Type.NumIn(); j++ {
+				in = append(in, got.Type.In(j))
+			}
+			out := make([]reflect.Type, 0, got.Type.NumOut())
+			for j := 0; j < got.Type.NumOut(); j++ {
+				out = append(out, got.Type.Out(j))
+			}
+			if reflect.FuncOf(in, out, got.Type.IsVariadic()) == want.Type {
+				continue
+			}
+		} else if got.Type == want.Type {
+			continue
+		}
+		missing = append(missing, want.Name+" (wrong signature)")
+	}
+	return missing
+}
+
+func isKind(kind reflect.Kind, actual any, data []any) (bool, []any) {
+	t := reflect.TypeOf(actual)
+	if t != nil && t.Kind() == kind {
+		return true, data
+	}
+	actualKind := "nil"
+	if t != nil {
+		actualKind = t.Kind().String()
+	}
+	return false, append(data, "expected_kind", kind.String(), "actual_kind", actualKind, "actual_type", typeName(t))
+}
+
+// typeName names t including its package path, so types of the same name in different
+// packages are told apart
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "nil"
+	}
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	return t.String()
+}
@@ -0,0 +1,53 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// halfReader has Read with the wrong signature and lacks Close
+type halfReader struct{}
+
+func (halfReader) Read(p []byte) int { return 0 }
+
+func TestTypeAssertions(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+
+	handler.IsType(ctx, &bytes.Buffer{}, &buffer, "Wrong Plugin Type")
+	handler.Implements(ctx, (*io.Writer)(nil), &buffer, "Plugin Not A Writer")
+	handler.IsKind(ctx, reflect.Func, handler.Assert, "Hook Not A Func")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	handler.IsType(ctx, &bytes.Buffer{}, strings.NewReader(""), "Wrong Plugin Type")
+	if !strings.Contains(buffer.String(), "expected_type=*bytes.Buffer") || !strings.Contains(buffer.String(), "actual_type=*strings.Reader") {
+		t.Fatalf("Expected both types in the failure, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.Implements(ctx, (*io.ReadCloser)(nil), halfReader{}, "Plugin Not A ReadCloser")
+	for _, want := range []string{"interface=io.ReadCloser", "actual_type=github.com/ZanzyTHEbar/assert-lib.halfReader", "Close", "Read (wrong signature)"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the failure, got %q", want, buffer.String())
+		}
+	}
+
+	buffer.Reset()
+	handler.Implements(ctx, io.Reader(nil), &buffer, "Misused Implements")
+	if !strings.Contains(buffer.String(), "expected a nil pointer to an interface") {
+		t.Fatalf("Expected a usage error, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.IsKind(ctx, reflect.Pointer, nil, "Not A Pointer")
+	if !strings.Contains(buffer.String(), "actual_kind=nil") {
+		t.Fatalf("Expected nil to fail with its kind, got %q", buffer.String())
+	}
+}
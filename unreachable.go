@@ -0,0 +1,72 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Unreachable reports a failure for code that must never execute and then always panics with
+// the *AssertionError, whatever the exit function, deferred mode or kind actions are. It never
+// returns; the error result only exists so it can end a function that needs a terminating
+// statement:
+//
+//	default:
+//		panic(assert.Unreachable(ctx, "unknown state", "state", s))
+func (a *AssertHandler) Unreachable(ctx context.Context, msg string, data ...any) error {
+	a.unreachable(ctx, 2, msg, data)
+	return nil
+}
+
+// Unreachable reports through the default handler and panics; see AssertHandler.Unreachable
+func Unreachable(ctx context.Context, msg string, data ...any) error {
+	a := handlerFor(ctx)
+	if h, ok := a.(*AssertHandler); ok {
+		h.unreachable(ctx, 2, msg, data)
+	}
+
+	// an injected Asserter may return normally; keep the guarantee anyway
+	a.Unreachable(ctx, msg, data...)
+	panic(&AssertionError{Msg: msg, Frames: framesOf(callerFrames()), Time: time.Now()})
+}
+
+func (a *AssertHandler) unreachable(ctx context.Context, skip int, msg string, data []any) {
+	caller := callerLocation(skip + 1)
+	function := callerFunction(skip + 1)
+	data = append(data, "caller", caller, "function", function)
+
+	err := &AssertionError{
+		Msg:    msg,
+		Data:   map[string]interface{}{"caller": caller, "function": function},
+		Frames: framesOf(callerFrames()),
+		Stack:  string(debug.Stack()),
+		Time:   time.Now(),
+	}
+
+	// report may itself panic or exit; if it returns, the panic below still guarantees
+	// that control never continues past the call
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if e, ok := r.(*AssertionError); ok {
+					err = e
+					return
+				}
+				panic(r)
+			}
+		}()
+		a.report(ctx, failure{severity: SeverityFatal, msg: msg, args: data})
+	}()
+
+	panic(err)
+}
+
+func callerLocation(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,33 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestUnreachablePanicsWithNoopExit(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+
+	state := func(s int) string {
+		switch s {
+		case 0:
+			return "idle"
+		default:
+			panic(handler.Unreachable(context.TODO(), "Unknown State", "state", s))
+		}
+	}
+
+	defer func() {
+		err, ok := recover().(*AssertionError)
+		if !ok || err.Msg != "Unknown State" {
+			t.Fatalf("Expected Unreachable to panic with an AssertionError, got %v", err)
+		}
+		if !bytes.Contains(buffer.Bytes(), []byte("caller=")) {
+			t.Fatalf("Expected caller in output")
+		}
+	}()
+	state(3)
+	t.Fatalf("Expected Unreachable not to return")
+}
@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// UserMessageData is available to WithUserFacingMessage templates
+type UserMessageData struct {
+	Message   string
+	Code      string
+	CrashFile string
+	Time      time.Time
+}
+
+// WithUserFacingMessage replaces the technical output of failures that exit the process
+// with a friendly message rendered from tmpl, a text/template over UserMessageData:
+//
+//	assert.WithUserFacingMessage("internal error, please report code {{.Code}} with {{.CrashFile}}")
+//
+// The full event still reaches the crash file and exporters. Invalid templates panic.
+func WithUserFacingMessage(tmpl string) Option {
+	t := template.Must(template.New("user-message").Parse(tmpl))
+	return func(a *AssertHandler) {
+		a.userMessage = t
+	}
+}
+
+// exitsImmediately reports whether f will run the exit path as soon as it is reported.
+// Callers must hold flushLock.
+func (a *AssertHandler) exitsImmediately(ctx context.Context, f failure) bool {
+	return f.severity.exits() &&
+		a.kindActions[f.kind] == ActionExit &&
+		deferredScopeFrom(ctx, a.deferred) == nil &&
+		!a.deferAssertions
+}
+
+// writeUserMessage renders the user-facing message to the writer. Callers must hold flushLock.
+func (a *AssertHandler) writeUserMessage(msg string, data map[string]interface{}) {
+	code := ""
+	if c, ok := data["code"]; ok {
+		code = fmt.Sprint(c)
+	}
+
+	var buf bytes.Buffer
+	err := a.userMessage.Execute(&buf, UserMessageData{
+		Message:   msg,
+		Code:      code,
+		CrashFile: a.crashFile,
+		Time:      time.Now(),
+	})
+	if err != nil {
+		fmt.Fprintln(a.writer, "User message error:", err)
+		return
+	}
+	fmt.Fprintln(a.writer, buf.String())
+}
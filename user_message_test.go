@@ -0,0 +1,38 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUserFacingMessage(t *testing.T) {
+	var buffer bytes.Buffer
+	crashFile := filepath.Join(t.TempDir(), "crash.log")
+	handler := NewAssertHandler(
+		WithWriter(&buffer),
+		WithExitFunc(func(code int) {}),
+		WithCrashOnFailure(crashFile),
+		WithUserFacingMessage("internal error, please report code {{.Code}} with {{.CrashFile}}"),
+	)
+
+	handler.Assert(context.TODO(), false, "Index Corrupted", "code", "E042", "segment", 7)
+
+	want := "internal error, please report code E042 with " + crashFile + "\n"
+	if buffer.String() != want {
+		t.Fatalf("Expected only the friendly message, got %q", buffer.String())
+	}
+	contents, err := os.ReadFile(crashFile)
+	if err != nil || !bytes.Contains(contents, []byte("segment=7")) {
+		t.Fatalf("Expected the technical event in the crash file: %v", err)
+	}
+
+	buffer.Reset()
+	handler.NoError(context.TODO(), nil, "No Failure")
+	handler.With(WithErrorSeverityMapper(func(error) Severity { return SeverityWarn })).NoError(context.TODO(), os.ErrClosed, "Soft Warning")
+	if !bytes.Contains(buffer.Bytes(), []byte("msg=Soft Warning")) {
+		t.Fatalf("Expected non-exiting failures to keep their technical output")
+	}
+}
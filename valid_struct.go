@@ -0,0 +1,171 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldViolation is a struct field breaking a rule of its assert tag
+type fieldViolation struct {
+	field string
+	rule  string
+	value any
+	err   string
+}
+
+// ValidStruct fails once per field of the struct v, or pointer to one, that breaks a rule of
+// its assert tag. Rules are separated by commas:
+//
+//	nonzero      the field is not the zero value of its type
+//	min=N        numbers are at least N; strings, slices and maps have at least N elements
+//	max=N        numbers are at most N; strings, slices and maps have at most N elements
+//	oneof=a|b|c  the field, printed with %v, is one of the listed values
+//
+// Nested structs are validated as well, with their fields named by path. Outside of
+// deferred mode the failures are collected in a deferred scope and processed together, so
+// every violated field is reported before the exit path runs.
+func (a *AssertHandler) ValidStruct(ctx context.Context, v any, msg string, data ...any) {
+	violations := validateStruct(v)
+	if len(violations) == 0 {
+		a.passed(KindValidation)
+		return
+	}
+
+	a.flushLock.Lock()
+	collecting := a.deferAssertions || deferredScopeFrom(ctx, a.deferred) != nil
+	a.flushLock.Unlock()
+	if !collecting {
+		var done func()
+		ctx, done = a.BeginDeferredScope(ctx)
+		defer done()
+	}
+	for _, violation := range violations {
+		a.evaluate(ctx, KindValidation, false, msg, violation.data(data))
+	}
+}
+
+// ValidStruct validates v through the default handler. Asserters other than *AssertHandler
+// report the violations one by one.
+func ValidStruct(ctx context.Context, v any, msg string, data ...any) {
+	a := handlerFor(ctx)
+	if h, ok := a.(*AssertHandler); ok {
+		h.ValidStruct(ctx, v, msg, data...)
+		return
+	}
+	for _, violation := range validateStruct(v) {
+		a.Assert(ctx, false, msg, violation.data(data)...)
+	}
+}
+
+func (v fieldViolation) data(data []any) []any {
+	return append(append([]any{}, data...), "field", v.field, "rule", v.rule, "value", v.value, "error", v.err)
+}
+
+// validateStruct returns the violations of v's assert tags
+func validateStruct(v any) []fieldViolation {
+	rv := reflect.ValueOf(v)
+	visiting := map[uintptr]bool{}
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		visiting[rv.Pointer()] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return []fieldViolation{{rule: "struct", value: v, err: fmt.Sprintf("expected a struct, got %T", v)}}
+	}
+	var violations []fieldViolation
+	validateFields(rv, "", visiting, &violations)
+	return violations
+}
+
+// validateFields appends the violations of rv's fields. visiting holds the pointers being
+// validated on the way to rv, so a cycle of pointers is validated once instead of forever.
+func validateFields(rv reflect.Value, prefix string, visiting map[uintptr]bool, violations *[]fieldViolation) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := prefix + field.Name
+		fv := rv.Field(i)
+
+		if tag, ok := field.Tag.Lookup("assert"); ok && tag != "-" {
+			for _, rule := range strings.Split(tag, ",") {
+				if rule = strings.TrimSpace(rule); rule == "" {
+					continue
+				}
+				if err := checkRule(fv, rule); err != "" {
+					*violations = append(*violations, fieldViolation{field: name, rule: rule, value: fv.Interface(), err: err})
+				}
+			}
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct:
+			validateFields(fv, name+".", visiting, violations)
+		case fv.Kind() == reflect.Pointer && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct:
+			if ptr := fv.Pointer(); !visiting[ptr] {
+				visiting[ptr] = true
+				validateFields(fv.Elem(), name+".", visiting, violations)
+				delete(visiting, ptr)
+			}
+		}
+	}
+}
+
+// checkRule returns why fv breaks rule, or "" if it does not
+func checkRule(fv reflect.Value, rule string) string {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "nonzero":
+		if fv.IsZero() {
+			return "must not be zero"
+		}
+	case "min", "max":
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Sprintf("invalid %s limit %q", name, arg)
+		}
+		n, measure, ok := ruleMeasure(fv)
+		if !ok {
+			return fmt.Sprintf("%s does not apply to %s", name, fv.Type())
+		}
+		if name == "min" && n < limit {
+			return fmt.Sprintf("%s must be at least %s", measure, arg)
+		}
+		if name == "max" && n > limit {
+			return fmt.Sprintf("%s must be at most %s", measure, arg)
+		}
+	case "oneof":
+		value := fmt.Sprint(fv.Interface())
+		for _, allowed := range strings.Split(arg, "|") {
+			if value == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s", strings.ReplaceAll(arg, "|", ", "))
+	default:
+		return fmt.Sprintf("unknown rule %q", name)
+	}
+	return ""
+}
+
+// ruleMeasure returns the number min and max compare: the value of numbers, the length of
+// strings, slices, arrays and maps
+func ruleMeasure(fv reflect.Value) (float64, string, bool) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), "value", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(fv.Uint()), "value", true
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), "value", true
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(fv.Len()), "length", true
+	default:
+		return 0, "", false
+	}
+}
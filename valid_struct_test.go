@@ -0,0 +1,80 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+type limits struct {
+	Burst int `assert:"min=1"`
+}
+
+type serviceConfig struct {
+	Name     string   `assert:"nonzero,max=8"`
+	Workers  int      `assert:"min=1,max=10"`
+	Mode     string   `assert:"oneof=fast|safe"`
+	Backends []string `assert:"min=1"`
+	Limits   *limits
+	Ignored  string `assert:"-"`
+	secret   string
+}
+
+func TestValidStruct(t *testing.T) {
+	var buffer bytes.Buffer
+	exits := 0
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) { exits++ }))
+	ctx := context.TODO()
+
+	valid := serviceConfig{Name: "billing", Workers: 4, Mode: "safe", Backends: []string{"db"}, Limits: &limits{Burst: 2}}
+	handler.ValidStruct(ctx, &valid, "Invalid Config")
+	if buffer.Len() != 0 || exits != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	invalid := serviceConfig{Name: "", Workers: 11, Mode: "slow", Limits: &limits{}}
+	handler.ValidStruct(ctx, invalid, "Invalid Config")
+	for _, want := range []string{
+		"field=Name", "rule=nonzero", "error=must not be zero",
+		"field=Workers", "error=value must be at most 10",
+		"field=Mode", "error=must be one of fast, safe",
+		"field=Backends", "error=length must be at least 1",
+		"field=Limits.Burst",
+	} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Fatalf("Expected %q in the failures, got %q", want, buffer.String())
+		}
+	}
+	if exits != 1 {
+		t.Fatalf("Expected all violations to be processed together with one exit, got %d", exits)
+	}
+
+	buffer.Reset()
+	scoped, done := handler.BeginDeferredScope(ctx)
+	handler.ValidStruct(scoped, serviceConfig{Name: "too-long-name", Workers: 1, Mode: "fast", Backends: []string{"db"}}, "Invalid Config")
+	if err := handler.ScopeError(scoped); err == nil || !strings.Contains(err.Error(), "Invalid Config") {
+		t.Fatalf("Expected the violation to join the caller's scope, got %v", err)
+	}
+	done()
+
+	buffer.Reset()
+	handler.ValidStruct(ctx, 42, "Invalid Config")
+	if !strings.Contains(buffer.String(), "expected a struct, got int") {
+		t.Fatalf("Expected a usage error, got %q", buffer.String())
+	}
+}
+
+type validNode struct {
+	Name string `assert:"nonzero"`
+	Next *validNode
+}
+
+func TestValidStructCycle(t *testing.T) {
+	first := &validNode{Name: "first"}
+	first.Next = &validNode{Next: first}
+	violations := validateStruct(first)
+	if len(violations) != 1 || violations[0].field != "Next.Name" {
+		t.Fatalf("Expected the cycle to be validated once, got %+v", violations)
+	}
+}
@@ -0,0 +1,164 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// ValidUUID fails when s is not a UUID in its canonical 8-4-4-4-12 hex form
+func (a *AssertHandler) ValidUUID(ctx context.Context, s string, msg string, data ...any) {
+	ok, data := valid(s, parseUUID(s), data)
+	a.evaluate(ctx, KindValidation, ok, msg, data)
+}
+
+// ValidUUID fails through the default handler when s is not a UUID
+func ValidUUID(ctx context.Context, s string, msg string, data ...any) {
+	ok, data := valid(s, parseUUID(s), data)
+	validFor(ctx, ok, msg, data)
+}
+
+// ValidURL fails when s is not an absolute URL with a scheme and a host
+func (a *AssertHandler) ValidURL(ctx context.Context, s string, msg string, data ...any) {
+	ok, data := valid(s, parseURL(s), data)
+	a.evaluate(ctx, KindValidation, ok, msg, data)
+}
+
+// ValidURL fails through the default handler when s is not an absolute URL
+func ValidURL(ctx context.Context, s string, msg string, data ...any) {
+	ok, data := valid(s, parseURL(s), data)
+	validFor(ctx, ok, msg, data)
+}
+
+// ValidEmail fails when s is not a bare RFC 5322 address such as ada@example.com; addresses
+// with a display name are rejected
+func (a *AssertHandler) ValidEmail(ctx context.Context, s string, msg string, data ...any) {
+	ok, data := valid(s, parseEmail(s), data)
+	a.evaluate(ctx, KindValidation, ok, msg, data)
+}
+
+// ValidEmail fails through the default handler when s is not a bare email address
+func ValidEmail(ctx context.Context, s string, msg string, data ...any) {
+	ok, data := valid(s, parseEmail(s), data)
+	validFor(ctx, ok, msg, data)
+}
+
+// ValidIP fails when s is not an IPv4 or IPv6 address
+func (a *AssertHandler) ValidIP(ctx context.Context, s string, msg string, data ...any) {
+	_, err := netip.ParseAddr(s)
+	ok, data := valid(s, err, data)
+	a.evaluate(ctx, KindValidation, ok, msg, data)
+}
+
+// ValidIP fails through the default handler when s is not an IP address
+func ValidIP(ctx context.Context, s string, msg string, data ...any) {
+	_, err := netip.ParseAddr(s)
+	ok, data := valid(s, err, data)
+	validFor(ctx, ok, msg, data)
+}
+
+// ValidPort fails when port, an integer or a string, is not a port number from 1 to 65535
+func (a *AssertHandler) ValidPort(ctx context.Context, port any, msg string, data ...any) {
+	ok, data := valid(port, parsePort(port), data)
+	a.evaluate(ctx, KindValidation, ok, msg, data)
+}
+
+// ValidPort fails through the default handler when port is not a port number from 1 to 65535
+func ValidPort(ctx context.Context, port any, msg string, data ...any) {
+	ok, data := valid(port, parsePort(port), data)
+	validFor(ctx, ok, msg, data)
+}
+
+// validFor evaluates a validation through the handler on ctx or the default handler
+func validFor(ctx context.Context, ok bool, msg string, data []any) {
+	a := handlerFor(ctx)
+	if h, isHandler := a.(*AssertHandler); isHandler {
+		h.evaluate(ctx, KindValidation, ok, msg, data)
+		return
+	}
+	a.Assert(ctx, ok, msg, data...)
+}
+
+// valid adds the value and the parse error to data when err is not nil
+func valid(value any, err error, data []any) (bool, []any) {
+	if err == nil {
+		return true, data
+	}
+	return false, append(data, "value", value, "error", err)
+}
+
+func parseUUID(s string) error {
+	if len(s) != 36 {
+		return fmt.Errorf("invalid length %d, expected 36", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return fmt.Errorf("expected '-' at offset %d, got %q", i, c)
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return fmt.Errorf("invalid hex digit %q at offset %d", c, i)
+			}
+		}
+	}
+	return nil
+}
+
+func parseURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" {
+		return errors.New("missing scheme")
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return nil
+}
+
+func parseEmail(s string) error {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return err
+	}
+	if addr.Name != "" || addr.Address != s {
+		return fmt.Errorf("expected a bare address, got name %q and address %q", addr.Name, addr.Address)
+	}
+	return nil
+}
+
+func parsePort(port any) error {
+	var n int64
+	v := reflect.ValueOf(port)
+	switch v.Kind() {
+	case reflect.String:
+		parsed, err := strconv.ParseInt(v.String(), 10, 64)
+		if err != nil {
+			return err
+		}
+		n = parsed
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > 65535 {
+			return fmt.Errorf("port %d out of range 1-65535", v.Uint())
+		}
+		n = int64(v.Uint())
+	default:
+		return fmt.Errorf("unsupported port type %T", port)
+	}
+	if n < 1 || n > 65535 {
+		return fmt.Errorf("port %d out of range 1-65535", n)
+	}
+	return nil
+}
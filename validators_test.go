@@ -0,0 +1,49 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+
+	handler.ValidUUID(ctx, "123e4567-E89B-12d3-a456-426614174000", "Invalid UUID")
+	handler.ValidURL(ctx, "https://example.com/path?q=1", "Invalid URL")
+	handler.ValidEmail(ctx, "ada@example.com", "Invalid Email")
+	handler.ValidIP(ctx, "10.0.0.1", "Invalid IP")
+	handler.ValidIP(ctx, "::1", "Invalid IP")
+	handler.ValidPort(ctx, 8080, "Invalid Port")
+	handler.ValidPort(ctx, "443", "Invalid Port")
+	handler.ValidPort(ctx, uint16(65535), "Invalid Port")
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	cases := []struct {
+		check func()
+		want  string
+	}{
+		{func() { handler.ValidUUID(ctx, "123e4567-e89b-12d3-a456-42661417400g", "Invalid UUID") }, `invalid hex digit 'g' at offset 35`},
+		{func() { handler.ValidUUID(ctx, "123e4567e89b12d3a456426614174000", "Invalid UUID") }, "invalid length 32"},
+		{func() { handler.ValidURL(ctx, "example.com/path", "Invalid URL") }, "missing scheme"},
+		{func() { handler.ValidURL(ctx, "https://exa mple.com", "Invalid URL") }, "invalid character"},
+		{func() { handler.ValidEmail(ctx, "Ada <ada@example.com>", "Invalid Email") }, "expected a bare address"},
+		{func() { handler.ValidEmail(ctx, "ada.example.com", "Invalid Email") }, "missing '@'"},
+		{func() { handler.ValidIP(ctx, "10.0.0.256", "Invalid IP") }, "value=10.0.0.256"},
+		{func() { handler.ValidPort(ctx, 0, "Invalid Port") }, "port 0 out of range 1-65535"},
+		{func() { handler.ValidPort(ctx, "http", "Invalid Port") }, "invalid syntax"},
+		{func() { handler.ValidPort(ctx, 80.5, "Invalid Port") }, "unsupported port type float64"},
+	}
+	for _, c := range cases {
+		buffer.Reset()
+		c.check()
+		if !strings.Contains(buffer.String(), c.want) {
+			t.Fatalf("Expected %q in the failure, got %q", c.want, buffer.String())
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookConfig configures NewWebhookExporter. Zero values use defaults.
+type WebhookConfig struct {
+	// Client sends the requests, http.DefaultClient if nil
+	Client *http.Client
+	// Timeout bounds each request (default 10s)
+	Timeout time.Duration
+	// Header is added to every request, e.g. for authentication
+	Header http.Header
+	// Encode renders the request body of one event, e.g. as a Slack message.
+	// The default is the JSON form of WebhookPayload.
+	Encode func(event AssertionEvent) ([]byte, error)
+	// Batcher configures the asynchronous queue and the retries
+	Batcher BatcherConfig
+}
+
+// WebhookPayload is the default JSON body posted for an event
+type WebhookPayload struct {
+	Time        time.Time              `json:"time"`
+	Severity    string                 `json:"severity"`
+	Message     string                 `json:"message"`
+	Caller      string                 `json:"caller,omitempty"`
+	Fingerprint string                 `json:"fingerprint,omitempty"`
+	Data        map[string]interface{} `json:"data"`
+	Stack       string                 `json:"stack,omitempty"`
+}
+
+// Webhook is an Exporter posting every event to URL synchronously, one request per event.
+// Use NewWebhookExporter to get it behind an asynchronous queue with retries.
+type Webhook struct {
+	URL    string
+	Config WebhookConfig
+}
+
+// NewWebhookExporter returns an Exporter posting events to url from a background queue,
+// retrying failed requests with backoff
+func NewWebhookExporter(url string, cfg WebhookConfig) *Batcher {
+	return NewBatcher(&Webhook{URL: url, Config: cfg}, cfg.Batcher)
+}
+
+func (w *Webhook) Export(ctx context.Context, events []AssertionEvent) error {
+	for _, event := range events {
+		if err := w.post(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Webhook) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (w *Webhook) post(ctx context.Context, event AssertionEvent) error {
+	encode := w.Config.Encode
+	if encode == nil {
+		encode = encodeWebhookPayload
+	}
+	body, err := encode(event)
+	if err != nil {
+		return err
+	}
+
+	timeout := w.Config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, vs := range w.Config.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := w.Config.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("assert: webhook %s returned %s", w.URL, resp.Status)
+	}
+	return nil
+}
+
+func encodeWebhookPayload(event AssertionEvent) ([]byte, error) {
+	return json.Marshal(WebhookPayload{
+		Time:        event.Time,
+		Severity:    event.Severity.String(),
+		Message:     event.Message,
+		Caller:      event.Caller,
+		Fingerprint: event.Fingerprint,
+		Data:        marshalableData(event.Data, json.Marshal),
+		Stack:       event.Stack,
+	})
+}
@@ -0,0 +1,63 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookExporter(t *testing.T) {
+	var mu sync.Mutex
+	var payloads []WebhookPayload
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected headers: %v", r.Header)
+		}
+		var p WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("Invalid payload: %v", err)
+		}
+		payloads = append(payloads, p)
+	}))
+	defer server.Close()
+
+	exporter := NewWebhookExporter(server.URL, WebhookConfig{
+		Header:  http.Header{"Authorization": {"Bearer token"}},
+		Timeout: time.Second,
+		Batcher: BatcherConfig{MaxDelay: time.Millisecond, InitialBackoff: time.Millisecond},
+	})
+
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}), WithExporter(exporter))
+	handler.Assert(context.TODO(), false, "Invariant Broken", "code", "INV-9", "events", make(chan int))
+
+	if err := handler.Shutdown(context.TODO()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 || len(payloads) != 1 {
+		t.Fatalf("Expected one retried delivery, got %d attempts and %d payloads", attempts, len(payloads))
+	}
+	p := payloads[0]
+	if p.Message != "Invariant Broken" || p.Severity != "ERROR" || p.Data["code"] != "INV-9" || p.Caller == "" {
+		t.Fatalf("Unexpected payload: %+v", p)
+	}
+	if p.Data["marshal_fallback"] == nil {
+		t.Fatalf("Expected unmarshalable values to fall back, got %v", p.Data)
+	}
+}
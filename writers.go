@@ -0,0 +1,100 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// teeWriter is an additional output receiving every failure next to the handler's writer
+type teeWriter struct {
+	w io.Writer
+	// formatter overrides the handler's formatter for this writer when not nil
+	formatter EventFormatter
+}
+
+// WithWriters sends failures to each of ws in addition to the handler's writer, formatted
+// with the handler's formatter
+func WithWriters(ws ...io.Writer) Option {
+	return func(a *AssertHandler) {
+		for _, w := range ws {
+			a.writers = append(a.writers, teeWriter{w: w})
+		}
+	}
+}
+
+// WithFormattedWriter sends failures to w in addition to the handler's writer, formatted with
+// formatter, e.g. text on the console and JSON in a file
+func WithFormattedWriter(w io.Writer, formatter Formatter) Option {
+	return func(a *AssertHandler) {
+		a.writers = append(a.writers, teeWriter{w: w, formatter: asEventFormatter(formatter)})
+	}
+}
+
+// AddWriter sends failures to w in addition to the handler's writer. A nil formatter uses
+// the handler's formatter.
+func (a *AssertHandler) AddWriter(w io.Writer, formatter Formatter) {
+	a.flushLock.Lock()
+	defer a.flushLock.Unlock()
+	tee := teeWriter{w: w}
+	if formatter != nil {
+		tee.formatter = asEventFormatter(formatter)
+	}
+	a.writers = append(a.writers, tee)
+}
+
+// formatFor renders event for one additional writer, reusing the handler's output when
+// the writer has no formatter of its own. Callers must hold flushLock.
+func (a *AssertHandler) formatFor(tee teeWriter, event AssertionEvent, formatted string) string {
+	if tee.formatter == nil {
+		return formatted
+	}
+	out, err := tee.formatter.FormatEvent(event)
+	if err != nil {
+		fmt.Fprintln(a.writer, "Formatter error:", err)
+		return formatted
+	}
+	return string(out)
+}
+
+// writeTee writes a failure to the additional writers. Callers must hold flushLock.
+func (a *AssertHandler) writeTee(event AssertionEvent, formatted string) {
+	for _, tee := range a.writers {
+		a.writeRecord(tee.w, a.formatFor(tee, event, formatted))
+	}
+}
+
+// writeTeeDeferred writes processed deferred failures to the additional writers.
+// Callers must hold flushLock.
+func (a *AssertHandler) writeTeeDeferred(failures []deferredFailure) {
+	for _, tee := range a.writers {
+		formatted := make([]string, len(failures))
+		for i, f := range failures {
+			formatted[i] = a.formatFor(tee, f.event, f.formatted)
+		}
+		a.writeRecords(tee.w, formatted)
+	}
+}
+
+// flushWriters flushes the handler's writers that buffer output, such as AsyncWriter or
+// bufio.Writer, until ctx is done. Callers must hold flushLock.
+func (a *AssertHandler) flushWriters(ctx context.Context) {
+	writers := []io.Writer{a.writer}
+	for _, tee := range a.writers {
+		writers = append(writers, tee.w)
+	}
+	for _, w := range writers {
+		var err error
+		switch f := w.(type) {
+		case exportFlusher:
+			err = f.Flush(ctx)
+		case interface{ Flush() error }:
+			err = f.Flush()
+		}
+		if err != nil {
+			a.flushFailed(err)
+			fmt.Fprintln(os.Stderr, "assert: writer flush error:", err)
+		}
+	}
+}
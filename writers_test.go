@@ -0,0 +1,38 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMultipleWriters(t *testing.T) {
+	var console, mirror, file bytes.Buffer
+	handler := NewAssertHandler(
+		WithWriter(&console),
+		WithExitFunc(func(code int) {}),
+		WithWriters(&mirror),
+		WithFormattedWriter(&file, &JSONFormatter{Compact: true}),
+	)
+
+	handler.Assert(context.TODO(), false, "Tee Failure")
+
+	if !strings.Contains(console.String(), "msg=Tee Failure") || console.String() != mirror.String() {
+		t.Fatalf("Expected the mirror to receive the console output, got %q and %q", console.String(), mirror.String())
+	}
+	if !strings.Contains(file.String(), `{"assertData":{"msg":"Tee Failure"`) {
+		t.Fatalf("Expected JSON in the file writer, got %q", file.String())
+	}
+
+	var late bytes.Buffer
+	handler.AddWriter(&late, &YAMLFormatter{})
+	handler.SetDeferAssertions(true)
+	handler.Assert(context.TODO(), false, "Deferred One")
+	handler.Assert(context.TODO(), false, "Deferred Two")
+	late.Reset()
+	handler.ProcessDeferredAssertions(context.TODO())
+	if !strings.HasPrefix(late.String(), "assertData:\n  msg: Deferred One") || !strings.Contains(late.String(), "---\nassertData:\n  msg: Deferred Two") {
+		t.Fatalf("Expected processed deferred failures in YAML, got %q", late.String())
+	}
+}
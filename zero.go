@@ -0,0 +1,41 @@
+package assert
+
+import (
+	"context"
+	"reflect"
+)
+
+// Zero fails when v is not the zero value of its type. A nil v counts as zero.
+func (a *AssertHandler) Zero(ctx context.Context, v any, msg string, data ...any) {
+	ok, data := zero(v, true, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// Zero fails through the default handler when v is not the zero value of its type
+func Zero(ctx context.Context, v any, msg string, data ...any) {
+	ok, data := zero(v, true, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// NotZero fails when v is nil or the zero value of its type, e.g. an unset config field:
+// 0, "", false, a nil pointer, map or slice, or a struct with only zero fields. Empty but
+// non-nil maps and slices are not zero.
+func (a *AssertHandler) NotZero(ctx context.Context, v any, msg string, data ...any) {
+	ok, data := zero(v, false, data)
+	a.evaluate(ctx, KindAssert, ok, msg, data)
+}
+
+// NotZero fails through the default handler when v is nil or the zero value of its type
+func NotZero(ctx context.Context, v any, msg string, data ...any) {
+	ok, data := zero(v, false, data)
+	handlerFor(ctx).Assert(ctx, ok, msg, data...)
+}
+
+// zero reports whether v is zero as wanted, adding the value and its type otherwise
+func zero(v any, want bool, data []any) (bool, []any) {
+	isZero := v == nil || reflect.ValueOf(v).IsZero()
+	if isZero == want {
+		return true, data
+	}
+	return false, append(data, "value", v, "type", typeName(reflect.TypeOf(v)))
+}
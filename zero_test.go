@@ -0,0 +1,41 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestZeroAndNotZero(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := NewAssertHandler(WithWriter(&buffer), WithExitFunc(func(code int) {}))
+	ctx := context.TODO()
+
+	type config struct {
+		Port    int
+		Timeout time.Duration
+	}
+	var nilMap map[string]int
+	for _, v := range []any{nil, 0, "", false, (*config)(nil), nilMap, config{}, time.Time{}} {
+		handler.Zero(ctx, v, "Not Zero")
+	}
+	for _, v := range []any{1, "x", true, &config{}, map[string]int{}, config{Port: 80}, time.Now()} {
+		handler.NotZero(ctx, v, "Zero")
+	}
+	if buffer.Len() != 0 {
+		t.Fatalf("Expected no failures, got %q", buffer.String())
+	}
+
+	handler.NotZero(ctx, config{}, "Config Unset")
+	if !strings.Contains(buffer.String(), "type=github.com/ZanzyTHEbar/assert-lib.config") {
+		t.Fatalf("Expected the type in the failure, got %q", buffer.String())
+	}
+
+	buffer.Reset()
+	handler.Zero(ctx, 8080, "Port Set")
+	if !strings.Contains(buffer.String(), "value=8080") || !strings.Contains(buffer.String(), "type=int") {
+		t.Fatalf("Expected the value and type in the failure, got %q", buffer.String())
+	}
+}